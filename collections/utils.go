@@ -1,6 +1,6 @@
 package collections
 
-import "math/bits"
+import "github.com/sinhashubham95/go-utils/numbers"
 
 type sortedHint int // hint for sort when choosing the pivot
 
@@ -47,8 +47,9 @@ func shiftDownOrdered[E any](data []E, lo, hi, first int, less func(a, b E) bool
 	}
 }
 
+// nextPowerOfTwo returns the smallest power of two strictly greater than the length.
 func nextPowerOfTwo(length int) uint {
-	return 1 << bits.Len(uint(length))
+	return uint(numbers.NextPowerOfTwo(length + 1))
 }
 
 func factorial(n int) int {
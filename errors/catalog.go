@@ -0,0 +1,179 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// Codes of the errors returned by the catalog
+const (
+	InvalidDefinitionErrorCode = "INVALID_ERROR_DEFINITION"
+	DuplicateCodeErrorCode     = "DUPLICATE_ERROR_CODE"
+)
+
+// Errors returned by the catalog
+var (
+	ErrInvalidDefinition = &Error{StatusCode: http.StatusInternalServerError, Code: InvalidDefinitionErrorCode}
+	ErrDuplicateCode     = &Error{StatusCode: http.StatusInternalServerError, Code: DuplicateCodeErrorCode}
+)
+
+// Definition is the declaration of an error, from which the errors with its code are created.
+type Definition struct {
+	// Code is the unique code of the error.
+	Code string `json:"code"`
+	// Message is the default message of the error, which can have the verbs of the fmt package
+	// to be filled with the arguments given while creating the error using New,
+	// or the named placeholders to be filled with the params given while creating it using NewWithParams.
+	Message string `json:"message"`
+	// StatusCode is the HTTP status code of the error, the default one of the category if not set.
+	StatusCode int `json:"status"`
+	// GRPCCode is the gRPC status code of the error, the one corresponding to the HTTP status code if not set.
+	GRPCCode GRPCCode `json:"grpcCode"`
+	// Retryable tells whether the operation failing with the error can be retried.
+	Retryable bool `json:"retryable"`
+	// Severity is the severity of the error, the default one of the category if not set.
+	Severity Severity `json:"severity"`
+	// Category is the category of the error, the one corresponding to the status code if not set.
+	Category Category `json:"category,omitempty"`
+}
+
+// Catalog is the registry of the error definitions, where the errors of a service are declared once.
+// It is safe for concurrent use.
+type Catalog struct {
+	mu          sync.RWMutex
+	definitions map[string]Definition
+}
+
+// NewCatalog is used to create an empty catalog.
+func NewCatalog() *Catalog {
+	return &Catalog{definitions: make(map[string]Definition)}
+}
+
+// Register is used to add the error definitions to the catalog.
+//
+// It fails with ErrInvalidDefinition if any definition does not have a code, and with ErrDuplicateCode if the code
+// is already registered or repeated in the definitions. In both the cases none of the definitions is added.
+func (c *Catalog) Register(definitions ...Definition) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	codes := make(map[string]struct{}, len(definitions))
+	for _, d := range definitions {
+		if d.Code == "" {
+			return ErrInvalidDefinition.WithMessage(fmt.Sprintf("error definition %q does not have a code", d.Message))
+		}
+		if _, ok := c.definitions[d.Code]; ok {
+			return ErrDuplicateCode.WithMessage(fmt.Sprintf("error code %q is already registered", d.Code))
+		}
+		if _, ok := codes[d.Code]; ok {
+			return ErrDuplicateCode.WithMessage(fmt.Sprintf("error code %q is repeated", d.Code))
+		}
+		codes[d.Code] = struct{}{}
+	}
+	for _, d := range definitions {
+		c.definitions[d.Code] = withDefaults(d)
+	}
+	return nil
+}
+
+// MustRegister is the same as Register, but it panics if the definitions cannot be registered.
+// It is meant to be used while initialising the package level variables.
+func (c *Catalog) MustRegister(definitions ...Definition) *Catalog {
+	if err := c.Register(definitions...); err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// Definition is used to get the definition registered with the given code.
+func (c *Catalog) Definition(code string) (Definition, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	d, ok := c.definitions[code]
+	return d, ok
+}
+
+// Definitions is used to get all the definitions registered, ordered by their codes.
+func (c *Catalog) Definitions() []Definition {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	r := make([]Definition, 0, len(c.definitions))
+	for _, d := range c.definitions {
+		r = append(r, d)
+	}
+	sort.Slice(r, func(i, j int) bool {
+		return r[i].Code < r[j].Code
+	})
+	return r
+}
+
+// New is used to create the error from the definition registered with the given code.
+// The message is formatted from the message of the definition using the arguments, if there are any.
+//
+// It panics if the code is not registered, since this is an error in the program rather than at runtime.
+func (c *Catalog) New(code string, args ...interface{}) *Error {
+	d, ok := c.Definition(code)
+	if !ok {
+		panic(fmt.Sprintf("error code %q is not registered", code))
+	}
+	message := d.Message
+	if len(args) > 0 {
+		message = fmt.Sprintf(message, args...)
+	}
+	return &Error{
+		StatusCode: d.StatusCode,
+		Code:       d.Code,
+		Message:    message,
+		GRPCCode:   d.GRPCCode,
+		Retryable:  d.Retryable,
+		Severity:   d.Severity,
+		Category:   d.Category,
+		stack:      callers(0),
+	}
+}
+
+// NewWithParams is used to create the error from the definition registered with the given code,
+// keeping the message of the definition as the template to be rendered with the params.
+//
+// It panics if the code is not registered, since this is an error in the program rather than at runtime.
+func (c *Catalog) NewWithParams(code string, params Params) *Error {
+	d, ok := c.Definition(code)
+	if !ok {
+		panic(fmt.Sprintf("error code %q is not registered", code))
+	}
+	return &Error{
+		StatusCode: d.StatusCode,
+		Code:       d.Code,
+		Message:    d.Message,
+		Params:     params,
+		GRPCCode:   d.GRPCCode,
+		Retryable:  d.Retryable,
+		Severity:   d.Severity,
+		Category:   d.Category,
+		stack:      callers(0),
+	}
+}
+
+// MarshalJSON is used to export the definitions registered, ordered by their codes,
+// for example to generate the client SDKs.
+func (c *Catalog) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.Definitions())
+}
+
+func withDefaults(d Definition) Definition {
+	if d.StatusCode == 0 {
+		d.StatusCode = d.Category.StatusCode()
+	}
+	if d.Category == "" {
+		d.Category = categoryFromStatusCode(d.StatusCode)
+	}
+	if d.GRPCCode == GRPCOK {
+		d.GRPCCode = GRPCCodeFromHTTPStatus(d.StatusCode)
+	}
+	if d.Severity == "" {
+		d.Severity = d.Category.Severity()
+	}
+	return d
+}
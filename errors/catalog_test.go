@@ -0,0 +1,154 @@
+package errors_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/sinhashubham95/go-utils/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCatalog() *errors.Catalog {
+	return errors.NewCatalog().MustRegister(
+		errors.Definition{
+			Code:       "USER_NOT_FOUND",
+			Message:    "user %s not found",
+			StatusCode: http.StatusNotFound,
+			GRPCCode:   errors.GRPCNotFound,
+			Severity:   errors.SeverityWarning,
+		},
+		errors.Definition{
+			Code:       "DEPENDENCY_UNAVAILABLE",
+			Message:    "dependency is unavailable",
+			StatusCode: http.StatusServiceUnavailable,
+			GRPCCode:   errors.GRPCUnavailable,
+			Retryable:  true,
+		},
+		errors.Definition{Code: "INTERNAL"},
+	)
+}
+
+func TestCatalogNew(t *testing.T) {
+	c := newCatalog()
+	e := c.New("USER_NOT_FOUND", "naruto")
+	assert.Equal(t, http.StatusNotFound, e.StatusCode)
+	assert.Equal(t, "USER_NOT_FOUND", e.Code)
+	assert.Equal(t, "user naruto not found", e.Message)
+	assert.Equal(t, errors.GRPCNotFound, e.GRPCCode)
+	assert.False(t, e.Retryable)
+	assert.Equal(t, errors.SeverityWarning, e.Severity)
+	assert.True(t, strings.HasSuffix(e.Frames()[0].Function, "errors_test.TestCatalogNew"))
+	assert.True(t, errors.Is(e, &errors.Error{Code: "USER_NOT_FOUND"}))
+
+	e = c.New("DEPENDENCY_UNAVAILABLE")
+	assert.Equal(t, "dependency is unavailable", e.Message)
+	assert.True(t, e.Retryable)
+	assert.Equal(t, errors.SeverityError, e.Severity)
+
+	e = c.New("INTERNAL")
+	assert.Equal(t, http.StatusInternalServerError, e.StatusCode)
+	assert.Equal(t, errors.GRPCInternal, e.GRPCCode)
+	assert.Equal(t, "", e.Message)
+
+	assert.Panics(t, func() { c.New("NARUTO") })
+}
+
+func TestCatalogRegister(t *testing.T) {
+	c := newCatalog()
+	err := c.Register(errors.Definition{Code: "NARUTO"}, errors.Definition{Code: "USER_NOT_FOUND"})
+	assert.True(t, errors.Is(err, errors.ErrDuplicateCode))
+	assert.Equal(t, `error code "USER_NOT_FOUND" is already registered`, err.(*errors.Error).Message)
+	_, ok := c.Definition("NARUTO")
+	assert.False(t, ok)
+
+	err = c.Register(errors.Definition{Code: "NARUTO"}, errors.Definition{Code: "NARUTO"})
+	assert.True(t, errors.Is(err, errors.ErrDuplicateCode))
+	err = c.Register(errors.Definition{Message: "naruto"})
+	assert.True(t, errors.Is(err, errors.ErrInvalidDefinition))
+
+	assert.NoError(t, c.Register(errors.Definition{Code: "NARUTO"}))
+	d, ok := c.Definition("NARUTO")
+	assert.True(t, ok)
+	assert.Equal(t, errors.Definition{
+		Code:       "NARUTO",
+		StatusCode: http.StatusInternalServerError,
+		GRPCCode:   errors.GRPCInternal,
+		Severity:   errors.SeverityError,
+		Category:   errors.CategoryInternal,
+	}, d)
+	assert.Panics(t, func() { c.MustRegister(errors.Definition{Code: "NARUTO"}) })
+}
+
+func TestCatalogConcurrent(t *testing.T) {
+	c := newCatalog()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i += 1 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_ = c.Register(errors.Definition{Code: "NARUTO"})
+		}()
+		go func() {
+			defer wg.Done()
+			assert.Equal(t, "USER_NOT_FOUND", c.New("USER_NOT_FOUND", "naruto").Code)
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, c.Definitions(), 4)
+}
+
+func TestCatalogJSON(t *testing.T) {
+	b, err := json.Marshal(newCatalog())
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"code": "DEPENDENCY_UNAVAILABLE", "message": "dependency is unavailable", "status": 503, "grpcCode": 14,
+			"retryable": true, "severity": "error", "category": "dependency-failure"},
+		{"code": "INTERNAL", "message": "", "status": 500, "grpcCode": 13, "retryable": false, "severity": "error",
+			"category": "internal"},
+		{"code": "USER_NOT_FOUND", "message": "user %s not found", "status": 404, "grpcCode": 5,
+			"retryable": false, "severity": "warning", "category": "not-found"}
+	]`, string(b))
+}
+
+func TestGRPCCodeString(t *testing.T) {
+	assert.Equal(t, "OK", errors.GRPCOK.String())
+	assert.Equal(t, "NotFound", errors.GRPCNotFound.String())
+	assert.Equal(t, "Unauthenticated", errors.GRPCUnauthenticated.String())
+	assert.Equal(t, "Code(17)", errors.GRPCCode(17).String())
+}
+
+func TestGRPCCodeMapping(t *testing.T) {
+	for status, code := range map[int]errors.GRPCCode{
+		http.StatusOK: errors.GRPCOK, http.StatusBadRequest: errors.GRPCInvalidArgument,
+		http.StatusUnauthorized: errors.GRPCUnauthenticated, http.StatusForbidden: errors.GRPCPermissionDenied,
+		http.StatusNotFound: errors.GRPCNotFound, http.StatusConflict: errors.GRPCAlreadyExists,
+		http.StatusTooManyRequests: errors.GRPCResourceExhausted, 499: errors.GRPCCanceled,
+		http.StatusNotImplemented: errors.GRPCUnimplemented, http.StatusServiceUnavailable: errors.GRPCUnavailable,
+		http.StatusGatewayTimeout: errors.GRPCDeadlineExceeded, http.StatusInternalServerError: errors.GRPCInternal,
+	} {
+		assert.Equal(t, code, errors.GRPCCodeFromHTTPStatus(status), status)
+		assert.Equal(t, status, errors.HTTPStatusFromGRPCCode(code), code)
+	}
+	assert.Equal(t, errors.GRPCDeadlineExceeded, errors.GRPCCodeFromHTTPStatus(http.StatusRequestTimeout))
+	assert.Equal(t, errors.GRPCFailedPrecondition, errors.GRPCCodeFromHTTPStatus(http.StatusPreconditionFailed))
+	assert.Equal(t, errors.GRPCOutOfRange, errors.GRPCCodeFromHTTPStatus(http.StatusRequestedRangeNotSatisfiable))
+	assert.Equal(t, errors.GRPCFailedPrecondition, errors.GRPCCodeFromHTTPStatus(http.StatusTeapot))
+	assert.Equal(t, errors.GRPCInternal, errors.GRPCCodeFromHTTPStatus(http.StatusBadGateway))
+	assert.Equal(t, errors.GRPCOK, errors.GRPCCodeFromHTTPStatus(http.StatusNoContent))
+	assert.Equal(t, errors.GRPCUnknown, errors.GRPCCodeFromHTTPStatus(http.StatusFound))
+
+	assert.Equal(t, http.StatusBadRequest, errors.HTTPStatusFromGRPCCode(errors.GRPCFailedPrecondition))
+	assert.Equal(t, http.StatusBadRequest, errors.HTTPStatusFromGRPCCode(errors.GRPCOutOfRange))
+	assert.Equal(t, http.StatusConflict, errors.HTTPStatusFromGRPCCode(errors.GRPCAborted))
+	assert.Equal(t, http.StatusInternalServerError, errors.HTTPStatusFromGRPCCode(errors.GRPCUnknown))
+	assert.Equal(t, http.StatusInternalServerError, errors.HTTPStatusFromGRPCCode(errors.GRPCDataLoss))
+	assert.Equal(t, http.StatusInternalServerError, errors.HTTPStatusFromGRPCCode(errors.GRPCCode(42)))
+
+	assert.Equal(t, errors.GRPCNotFound, (&errors.Error{StatusCode: http.StatusNotFound}).GRPCStatusCode())
+	assert.Equal(t, errors.GRPCAborted,
+		(&errors.Error{StatusCode: http.StatusConflict, GRPCCode: errors.GRPCAborted}).GRPCStatusCode())
+	assert.Equal(t, errors.GRPCUnknown, (&errors.Error{Code: "NARUTO"}).GRPCStatusCode())
+}
@@ -0,0 +1,155 @@
+package errors
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"os"
+)
+
+// Category is the kind of failure the error represents, deciding its default status code, severity and whether
+// the operation failing with it can be retried.
+type Category string
+
+// Categories of the errors
+const (
+	CategoryValidation        Category = "validation"
+	CategoryNotFound          Category = "not-found"
+	CategoryConflict          Category = "conflict"
+	CategoryUnauthorized      Category = "unauthorized"
+	CategoryDependencyFailure Category = "dependency-failure"
+	CategoryTimeout           Category = "timeout"
+	CategoryCanceled          Category = "canceled"
+	CategoryInternal          Category = "internal"
+)
+
+// StatusCode returns the default HTTP status code of the errors of the category, 500 for the unknown ones.
+func (c Category) StatusCode() int {
+	switch c {
+	case CategoryValidation:
+		return http.StatusBadRequest
+	case CategoryNotFound:
+		return http.StatusNotFound
+	case CategoryConflict:
+		return http.StatusConflict
+	case CategoryUnauthorized:
+		return http.StatusUnauthorized
+	case CategoryDependencyFailure:
+		return http.StatusBadGateway
+	case CategoryTimeout:
+		return http.StatusGatewayTimeout
+	case CategoryCanceled:
+		return statusClientClosedRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// Severity returns the default severity of the errors of the category. The failures caused by the clients are
+// warnings, the canceled operations are informational, and the others are errors.
+func (c Category) Severity() Severity {
+	switch c {
+	case CategoryValidation, CategoryNotFound, CategoryConflict, CategoryUnauthorized:
+		return SeverityWarning
+	case CategoryCanceled:
+		return SeverityInfo
+	default:
+		return SeverityError
+	}
+}
+
+// Retryable returns whether the operations failing with the errors of the category can be retried,
+// which is the case for the failures of the dependencies and the timeouts.
+func (c Category) Retryable() bool {
+	return c == CategoryDependencyFailure || c == CategoryTimeout
+}
+
+// New is used to create the error of the category, having its default status code and severity.
+func (c Category) New(message string) *Error {
+	return &Error{
+		StatusCode: c.StatusCode(),
+		Message:    message,
+		Category:   c,
+		Severity:   c.Severity(),
+		stack:      callers(0),
+	}
+}
+
+// WithCategory is used to create a new error with the category changed
+func (e *Error) WithCategory(category Category) *Error {
+	c := e.clone()
+	c.Category = category
+	return c
+}
+
+// Classify is used to get the category of the error. It is empty if the error is nil.
+//
+// For an *errors.Error in the chain of the error, it is the category set on it, or the one corresponding to its
+// status code. If it has neither a category nor a status code other than the generic 500, like the ones created
+// using errors.New, it is the category of the error it wraps, if any. For an *errors.MultiError, it is the one
+// corresponding to its status code. Otherwise, the context
+// errors and the timeouts, like the ones of net.Error, are timeouts or canceled, the network errors and the
+// unexpected ends of the connections, like io.EOF, are failures of the dependencies, and the rest are internal.
+func Classify(err error) Category {
+	if err == nil {
+		return ""
+	}
+	if m, ok := err.(*MultiError); ok {
+		return categoryFromStatusCode(m.StatusCode())
+	}
+	var e *Error
+	if As(err, &e) {
+		if e.Category != "" {
+			return e.Category
+		}
+		if e.StatusCode == 0 || e.StatusCode == http.StatusInternalServerError {
+			// the generic status code tells nothing about the failure, unlike the cause
+			if cause := e.Unwrap(); cause != nil {
+				return Classify(cause)
+			}
+		}
+		return categoryFromStatusCode(e.StatusCode)
+	}
+	var m *MultiError
+	if As(err, &m) {
+		return categoryFromStatusCode(m.StatusCode())
+	}
+	var n net.Error
+	switch {
+	case Is(err, context.Canceled):
+		return CategoryCanceled
+	case Is(err, context.DeadlineExceeded), Is(err, os.ErrDeadlineExceeded):
+		return CategoryTimeout
+	case As(err, &n):
+		if n.Timeout() {
+			return CategoryTimeout
+		}
+		return CategoryDependencyFailure
+	case Is(err, io.EOF), Is(err, io.ErrUnexpectedEOF), Is(err, io.ErrClosedPipe), Is(err, net.ErrClosed):
+		return CategoryDependencyFailure
+	default:
+		return CategoryInternal
+	}
+}
+
+func categoryFromStatusCode(status int) Category {
+	switch status {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return CategoryUnauthorized
+	case http.StatusNotFound, http.StatusGone:
+		return CategoryNotFound
+	case http.StatusConflict, http.StatusPreconditionFailed:
+		return CategoryConflict
+	case http.StatusRequestTimeout, http.StatusGatewayTimeout:
+		return CategoryTimeout
+	case statusClientClosedRequest:
+		return CategoryCanceled
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusTooManyRequests:
+		return CategoryDependencyFailure
+	}
+	if status >= 400 && status < 500 {
+		return CategoryValidation
+	}
+	return CategoryInternal
+}
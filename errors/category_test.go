@@ -0,0 +1,125 @@
+package errors_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/sinhashubham95/go-utils/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestCategory(t *testing.T) {
+	assert.Equal(t, http.StatusBadRequest, errors.CategoryValidation.StatusCode())
+	assert.Equal(t, http.StatusNotFound, errors.CategoryNotFound.StatusCode())
+	assert.Equal(t, http.StatusConflict, errors.CategoryConflict.StatusCode())
+	assert.Equal(t, http.StatusUnauthorized, errors.CategoryUnauthorized.StatusCode())
+	assert.Equal(t, http.StatusBadGateway, errors.CategoryDependencyFailure.StatusCode())
+	assert.Equal(t, http.StatusGatewayTimeout, errors.CategoryTimeout.StatusCode())
+	assert.Equal(t, 499, errors.CategoryCanceled.StatusCode())
+	assert.Equal(t, http.StatusInternalServerError, errors.CategoryInternal.StatusCode())
+	assert.Equal(t, http.StatusInternalServerError, errors.Category("naruto").StatusCode())
+
+	assert.Equal(t, errors.SeverityWarning, errors.CategoryNotFound.Severity())
+	assert.Equal(t, errors.SeverityInfo, errors.CategoryCanceled.Severity())
+	assert.Equal(t, errors.SeverityError, errors.CategoryTimeout.Severity())
+
+	assert.True(t, errors.CategoryDependencyFailure.Retryable())
+	assert.True(t, errors.CategoryTimeout.Retryable())
+	assert.False(t, errors.CategoryInternal.Retryable())
+	assert.False(t, errors.CategoryValidation.Retryable())
+}
+
+func TestCategoryNew(t *testing.T) {
+	e := errors.CategoryConflict.New("naruto")
+	assert.Equal(t, http.StatusConflict, e.StatusCode)
+	assert.Equal(t, errors.CategoryConflict, e.Category)
+	assert.Equal(t, errors.SeverityWarning, e.Severity)
+	assert.NotEmpty(t, e.Frames())
+
+	c := e.WithCategory(errors.CategoryTimeout)
+	assert.Equal(t, errors.CategoryTimeout, c.Category)
+	assert.Equal(t, errors.CategoryConflict, e.Category)
+}
+
+func TestClassify(t *testing.T) {
+	assert.Equal(t, errors.Category(""), errors.Classify(nil))
+	assert.Equal(t, errors.CategoryCanceled, errors.Classify(context.Canceled))
+	assert.Equal(t, errors.CategoryTimeout, errors.Classify(fmt.Errorf("call: %w", context.DeadlineExceeded)))
+	assert.Equal(t, errors.CategoryTimeout, errors.Classify(os.ErrDeadlineExceeded))
+	assert.Equal(t, errors.CategoryTimeout, errors.Classify(&net.OpError{Op: "read", Err: timeoutError{}}))
+	assert.Equal(t, errors.CategoryDependencyFailure,
+		errors.Classify(&net.OpError{Op: "dial", Err: fmt.Errorf("connection refused")}))
+	assert.Equal(t, errors.CategoryDependencyFailure, errors.Classify(io.EOF))
+	assert.Equal(t, errors.CategoryDependencyFailure, errors.Classify(fmt.Errorf("read: %w", io.ErrUnexpectedEOF)))
+	assert.Equal(t, errors.CategoryInternal, errors.Classify(fmt.Errorf("naruto")))
+
+	assert.Equal(t, errors.CategoryNotFound, errors.Classify(&errors.Error{StatusCode: http.StatusNotFound}))
+	assert.Equal(t, errors.CategoryUnauthorized, errors.Classify(&errors.Error{StatusCode: http.StatusForbidden}))
+	assert.Equal(t, errors.CategoryValidation,
+		errors.Classify(&errors.Error{StatusCode: http.StatusUnprocessableEntity}))
+	assert.Equal(t, errors.CategoryDependencyFailure,
+		errors.Classify(&errors.Error{StatusCode: http.StatusServiceUnavailable}))
+	assert.Equal(t, errors.CategoryInternal, errors.Classify(&errors.Error{}))
+	assert.Equal(t, errors.CategoryTimeout, errors.Classify(fmt.Errorf("call: %w",
+		&errors.Error{StatusCode: http.StatusBadRequest, Category: errors.CategoryTimeout})))
+	assert.Equal(t, errors.CategoryNotFound, errors.Classify(errors.Join(
+		&errors.Error{StatusCode: http.StatusBadRequest}, &errors.Error{StatusCode: http.StatusNotFound})))
+
+	assert.Equal(t, errors.CategoryDependencyFailure, errors.Classify(errors.Wrap(errors.New("fetch"), io.EOF)))
+	assert.Equal(t, errors.CategoryTimeout, errors.Classify(fmt.Errorf("call: %w",
+		(&errors.Error{}).Wrap(context.DeadlineExceeded))))
+	assert.Equal(t, errors.CategoryNotFound, errors.Classify(errors.Wrap(errors.New("fetch"),
+		&errors.Error{StatusCode: http.StatusNotFound})))
+	assert.Equal(t, errors.CategoryInternal, errors.Classify(errors.Wrap(errors.New("fetch"), fmt.Errorf("naruto"))))
+	assert.Equal(t, errors.CategoryInternal, errors.Classify(errors.Wrap(
+		&errors.Error{StatusCode: http.StatusNotImplemented}, io.EOF)))
+	assert.Equal(t, errors.CategoryValidation, errors.Classify(errors.Wrap(
+		&errors.Error{StatusCode: http.StatusBadRequest}, context.DeadlineExceeded)))
+}
+
+func TestSeverityOf(t *testing.T) {
+	assert.Equal(t, errors.Severity(""), errors.SeverityOf(nil))
+	assert.Equal(t, errors.SeverityInfo, errors.SeverityOf(context.Canceled))
+	assert.Equal(t, errors.SeverityError, errors.SeverityOf(io.EOF))
+	assert.Equal(t, errors.SeverityWarning, errors.SeverityOf(&errors.Error{StatusCode: http.StatusNotFound}))
+	assert.Equal(t, errors.SeverityCritical, errors.SeverityOf(fmt.Errorf("call: %w",
+		&errors.Error{StatusCode: http.StatusNotFound, Severity: errors.SeverityCritical})))
+	assert.Equal(t, errors.SeverityCritical, errors.SeverityOf(errors.Join(fmt.Errorf("naruto"),
+		fmt.Errorf("call: %w", &errors.Error{StatusCode: http.StatusNotFound, Severity: errors.SeverityCritical}))))
+	assert.Equal(t, errors.SeverityInfo, errors.SeverityOf(errors.Join(
+		&errors.Error{StatusCode: http.StatusBadRequest},
+		errors.Join(&errors.Error{Severity: errors.SeverityInfo}, &errors.Error{Severity: errors.SeverityCritical}))))
+	assert.Equal(t, errors.SeverityWarning, errors.SeverityOf(errors.Join(
+		&errors.Error{StatusCode: http.StatusBadRequest}, &errors.Error{StatusCode: http.StatusNotFound})))
+}
+
+func TestStatusCodeCategory(t *testing.T) {
+	assert.Equal(t, http.StatusGatewayTimeout, errors.StatusCode(context.DeadlineExceeded))
+	assert.Equal(t, http.StatusBadGateway, errors.StatusCode(io.EOF))
+	assert.Equal(t, http.StatusInternalServerError, errors.StatusCode(fmt.Errorf("naruto")))
+}
+
+func TestCatalogCategory(t *testing.T) {
+	c := errors.NewCatalog().MustRegister(
+		errors.Definition{Code: "UPSTREAM_TIMEOUT", Category: errors.CategoryTimeout},
+		errors.Definition{Code: "USER_EXISTS", StatusCode: http.StatusConflict},
+	)
+	e := c.New("UPSTREAM_TIMEOUT")
+	assert.Equal(t, http.StatusGatewayTimeout, e.StatusCode)
+	assert.Equal(t, errors.CategoryTimeout, e.Category)
+	assert.Equal(t, errors.GRPCDeadlineExceeded, e.GRPCCode)
+	e = c.New("USER_EXISTS")
+	assert.Equal(t, errors.CategoryConflict, e.Category)
+	assert.Equal(t, errors.SeverityWarning, e.Severity)
+}
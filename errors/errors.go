@@ -1,9 +1,10 @@
 package errors
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"reflect"
-	"runtime/debug"
 )
 
 var errorType = reflect.TypeOf((*error)(nil)).Elem()
@@ -14,7 +15,13 @@ type Error struct {
 	Code       string      `json:"code"`
 	Message    string      `json:"message"`
 	Details    interface{} `json:"details,omitempty"`
-	trace      string
+	Params     Params      `json:"params,omitempty"`
+	GRPCCode   GRPCCode    `json:"-"`
+	Retryable  bool        `json:"-"`
+	Severity   Severity    `json:"-"`
+	Category   Category    `json:"-"`
+	fields     []field
+	stack      stack
 }
 
 // wrap is the method to be used to wrap the error
@@ -27,6 +34,11 @@ type unwrap interface {
 	Unwrap() error
 }
 
+// unwrapMulti is the method to be used to unwrap the error holding many errors
+type unwrapMulti interface {
+	Unwrap() []error
+}
+
 // is used to check if it matches the error provided
 type is interface {
 	Is(err error) bool
@@ -39,74 +51,113 @@ type as interface {
 
 // WithStatusCode is used to create a new error with the status code changed
 func (e *Error) WithStatusCode(statusCode int) *Error {
-	return &Error{
-		StatusCode: statusCode,
-		Code:       e.Code,
-		Message:    e.Message,
-		Details:    e.Details,
-		trace:      e.GetTrace(),
-	}
+	c := e.clone()
+	c.StatusCode = statusCode
+	return c
 }
 
 // WithMessage is used to create a new error with the message changed
 func (e *Error) WithMessage(message string) *Error {
-	return &Error{
-		StatusCode: e.StatusCode,
-		Code:       e.Code,
-		Message:    message,
-		Details:    e.Details,
-		trace:      e.GetTrace(),
-	}
+	c := e.clone()
+	c.Message = message
+	return c
 }
 
 // WithDetails is used to attach the details to the error response
 func (e *Error) WithDetails(details interface{}) *Error {
-	return &Error{
-		StatusCode: e.StatusCode,
-		Code:       e.Code,
-		Message:    e.Message,
-		Details:    details,
-		trace:      e.GetTrace(),
-	}
+	c := e.clone()
+	c.Details = details
+	return c
+}
+
+// WithGRPCCode is used to create a new error with the gRPC status code changed
+func (e *Error) WithGRPCCode(code GRPCCode) *Error {
+	c := e.clone()
+	c.GRPCCode = code
+	return c
+}
+
+// WithRetryable is used to create a new error marked as retryable or not
+func (e *Error) WithRetryable(retryable bool) *Error {
+	c := e.clone()
+	c.Retryable = retryable
+	return c
+}
+
+// WithSeverity is used to create a new error with the severity changed
+func (e *Error) WithSeverity(severity Severity) *Error {
+	c := e.clone()
+	c.Severity = severity
+	return c
 }
 
 // Value is used to get the reference to the value
 func (e *Error) Value() *Error {
-	return &Error{
-		StatusCode: e.StatusCode,
-		Code:       e.Code,
-		Message:    e.Message,
-		Details:    e.Details,
-		trace:      e.GetTrace(),
-	}
+	return e.clone()
 }
 
-// GetTrace is used to get the current trace
+// GetTrace is used to get the stack trace captured when the error was created.
+// It is empty for the errors created without New, for example the error literals.
 func (e *Error) GetTrace() string {
-	if e.trace == "" {
-		return string(debug.Stack())
+	return e.stack.String()
+}
+
+// Frames is used to get the frames of the stack trace captured when the error was created.
+// The frames are resolved only when this is called, which keeps creating the errors cheap.
+func (e *Error) Frames() []Frame {
+	return e.stack.frames()
+}
+
+// SkipFrames is used to create a new error with the top n frames of the stack trace removed.
+// It is useful for the helpers creating the errors, so that the trace starts at their caller.
+func (e *Error) SkipFrames(n int) *Error {
+	c := e.Value()
+	c.stack.skip += n
+	return c
+}
+
+// Format is used to format the error.
+//
+// The verbs %s and %v print the same as Error, and %q prints it quoted. The verb %+v additionally prints the
+// message, the stack trace and the error wrapped in the details.
+func (e *Error) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			_, _ = fmt.Fprint(s, e.Error())
+			if e.Code != "" && e.Message != "" {
+				_, _ = fmt.Fprintf(s, ": %s", e.RenderMessage())
+			}
+			for _, f := range e.Frames() {
+				_, _ = fmt.Fprintf(s, "\n%s", f)
+			}
+			if cause := e.Unwrap(); cause != nil {
+				_, _ = fmt.Fprintf(s, "\ncaused by: %+v", cause)
+			}
+			return
+		}
+		_, _ = fmt.Fprint(s, e.Error())
+	case 's':
+		_, _ = fmt.Fprint(s, e.Error())
+	case 'q':
+		_, _ = fmt.Fprintf(s, "%q", e.Error())
 	}
-	return e.trace
 }
 
 // Error is used to get the detail from the error
 func (e *Error) Error() string {
 	if e.Code == "" {
-		return e.Message
+		return e.RenderMessage()
 	}
 	return e.Code
 }
 
-// Wrap is used to wrap the error
+// Wrap is used to create a new error with the given error as its cause in the details.
+//
+// The error is never modified, so the package level sentinel errors can be wrapped concurrently. The new error has
+// the same code and message, so it still matches the error using Is.
 func (e *Error) Wrap(err error) error {
-	if err != nil {
-		if d, ok := err.(*Error); ok {
-			e.Details = d
-		} else {
-			e.Details = New(err.Error())
-		}
-	}
-	return e
+	return e.wrap(err, 1)
 }
 
 // Unwrap is used to unwrap the error to the details
@@ -123,7 +174,8 @@ func (e *Error) Unwrap() error {
 	return nil
 }
 
-// Is used to check if it matches the error provided
+// Is used to check if it matches the error provided, which is when they have the same code,
+// or the same message if they do not have a code.
 func (e *Error) Is(err error) bool {
 	return e != nil && err != nil && e.Error() == err.Error()
 }
@@ -131,26 +183,59 @@ func (e *Error) Is(err error) bool {
 // As is used to case the error type to the target
 func (e *Error) As(target interface{}) bool {
 	if t, ok := target.(*Error); ok {
-		t.StatusCode = e.StatusCode
-		t.Code = e.Code
-		t.Message = e.Message
-		t.Details = e.Details
+		*t = *e
 		return true
 	}
 	return false
 }
 
+// MarshalJSON is used to render the error, with the errors in the details other than Error and MultiError
+// rendered with just their message.
+//
+// The message is rendered with the params, and the message before rendering is kept in the template, if it has any
+// placeholders replaced, so that the clients can show the message as it is, or localize it using the params.
+func (e *Error) MarshalJSON() ([]byte, error) {
+	type plain Error
+	p := plain(*e)
+	switch d := e.Details.(type) {
+	case *Error, *MultiError, json.Marshaler:
+	case error:
+		p.Details = &Error{Message: d.Error()}
+	}
+	r := struct {
+		*plain
+		Template string `json:"template,omitempty"`
+	}{plain: &p}
+	if p.Message = e.RenderMessage(); p.Message != e.Message {
+		r.Template = e.Message
+	}
+	return json.Marshal(r)
+}
+
 // New is used to create a new error
 func New(message string) error {
 	return &Error{
 		StatusCode: http.StatusInternalServerError,
 		Message:    message,
-		trace:      string(debug.Stack()),
+		stack:      callers(0),
+	}
+}
+
+// NewSkip is used to create a new error, skipping the given number of frames of the stack trace,
+// with 0 identifying the caller of NewSkip.
+func NewSkip(message string, skip int) error {
+	return &Error{
+		StatusCode: http.StatusInternalServerError,
+		Message:    message,
+		stack:      callers(skip),
 	}
 }
 
 // Wrap is used to wrap the error into another
 func Wrap(parent, err error) error {
+	if e, ok := parent.(*Error); ok {
+		return e.wrap(err, 1)
+	}
 	if e, ok := parent.(wrap); ok {
 		return e.Wrap(err)
 	}
@@ -165,27 +250,22 @@ func Unwrap(err error) error {
 	return nil
 }
 
-// Is used to check if the errors match or not
+// Is used to check if the errors match or not.
+//
+// The error tree is examined depth-first, following both the errors having an Unwrap() error method and
+// the errors having an Unwrap() []error method, like the ones created using Join.
 func Is(err, target error) bool {
 	if target == nil {
 		return err == target
 	}
-	isComparable := reflect.TypeOf(target).Comparable()
-	for {
-		if isComparable && reflect.DeepEqual(err, target) {
-			return true
-		}
-		if x, ok := err.(is); ok && x.Is(target) {
-			return true
-		}
-		if err = Unwrap(err); err == nil {
-			return false
-		}
-	}
+	return isInTree(err, target, reflect.TypeOf(target).Comparable())
 }
 
-// As finds the first error in error's chain that matches target, and if so, sets
+// As finds the first error in error's tree that matches target, and if so, sets
 // target to that error value and returns true. Otherwise, it returns false.
+//
+// The error tree is examined depth-first, following both the errors having an Unwrap() error method and
+// the errors having an Unwrap() []error method, like the ones created using Join.
 func As(err error, target interface{}) bool {
 	if target == nil {
 		return false
@@ -198,7 +278,59 @@ func As(err error, target interface{}) bool {
 	if e := typ.Elem(); e.Kind() != reflect.Interface && e.Kind() != reflect.Struct && !e.Implements(errorType) {
 		return false
 	}
-	targetType := typ.Elem()
+	return asInTree(err, target, val, typ.Elem())
+}
+
+// clone returns a copy of the error, capturing the stack trace of the caller of its caller if there is none.
+func (e *Error) clone() *Error {
+	c := *e
+	if c.stack.empty() {
+		c.stack = callers(1)
+	}
+	return &c
+}
+
+// wrap creates the copy of the error with the given error in the details, capturing the stack trace if there is
+// none, with skip 0 identifying the caller of wrap.
+func (e *Error) wrap(err error, skip int) error {
+	if err == nil {
+		return e
+	}
+	c := *e
+	if c.stack.empty() {
+		c.stack = callers(skip)
+	}
+	c.Details = err
+	return &c
+}
+
+func isInTree(err, target error, isComparable bool) bool {
+	for {
+		if isComparable && reflect.DeepEqual(err, target) {
+			return true
+		}
+		if x, ok := err.(is); ok && x.Is(target) {
+			return true
+		}
+		switch x := err.(type) {
+		case unwrap:
+			if err = x.Unwrap(); err == nil {
+				return false
+			}
+		case unwrapMulti:
+			for _, e := range x.Unwrap() {
+				if isInTree(e, target, isComparable) {
+					return true
+				}
+			}
+			return false
+		default:
+			return false
+		}
+	}
+}
+
+func asInTree(err error, target interface{}, val reflect.Value, targetType reflect.Type) bool {
 	for err != nil {
 		if reflect.TypeOf(err).AssignableTo(targetType) {
 			val.Elem().Set(reflect.ValueOf(err))
@@ -207,7 +339,19 @@ func As(err error, target interface{}) bool {
 		if x, ok := err.(as); ok && x.As(target) {
 			return true
 		}
-		err = Unwrap(err)
+		switch x := err.(type) {
+		case unwrap:
+			err = x.Unwrap()
+		case unwrapMulti:
+			for _, e := range x.Unwrap() {
+				if asInTree(e, target, val, targetType) {
+					return true
+				}
+			}
+			return false
+		default:
+			return false
+		}
 	}
 	return false
 }
@@ -1,8 +1,10 @@
 package errors_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"sync"
 	"testing"
 
 	"github.com/sinhashubham95/go-utils/errors"
@@ -32,10 +34,16 @@ func TestError(t *testing.T) {
 
 func TestErrorWrapUnwrap(t *testing.T) {
 	e := errors.New("naruto")
-	assert.Equal(t, e, errors.Wrap(e, errors.New("naruto")))
-	assert.Error(t, errors.Unwrap(e))
-	e = errors.New("naruto")
-	assert.Equal(t, e, errors.Wrap(e, fmt.Errorf("naruto")))
+	cause := errors.New("boruto")
+	w := errors.Wrap(e, cause)
+	assert.NotSame(t, e, w)
+	assert.Equal(t, cause, errors.Unwrap(w))
+	assert.Nil(t, errors.Unwrap(e))
+	assert.Same(t, e, errors.Wrap(e, nil))
+	plain := fmt.Errorf("boruto")
+	w = errors.Wrap(e, plain)
+	assert.Equal(t, plain, errors.Unwrap(w))
+	assert.Equal(t, e.(*errors.Error).Frames(), w.(*errors.Error).Frames())
 	assert.Nil(t, (&errors.Error{Details: "naruto"}).Unwrap())
 	assert.Nil(t, errors.Wrap(nil, nil))
 	assert.Nil(t, errors.Unwrap(nil))
@@ -67,3 +75,97 @@ func TestErrorAs(t *testing.T) {
 	assert.Equal(t, http.StatusOK, ne.StatusCode)
 	assert.Equal(t, "naruto", ne.Message)
 }
+
+type multi []error
+
+func (m multi) Error() string {
+	return "multi"
+}
+
+func (m multi) Unwrap() []error {
+	return m
+}
+
+func TestErrorIsTree(t *testing.T) {
+	notFound := &errors.Error{Code: "NOT_FOUND"}
+	err := multi{
+		fmt.Errorf("naruto"),
+		multi{errors.New("sasuke"), errors.Wrap(errors.New("sakura"), notFound)},
+		nil,
+	}
+	assert.True(t, errors.Is(err, fmt.Errorf("naruto")))
+	assert.True(t, errors.Is(err, errors.New("sasuke")))
+	assert.True(t, errors.Is(err, &errors.Error{Code: "NOT_FOUND"}))
+	assert.False(t, errors.Is(err, errors.New("kakashi")))
+	assert.False(t, errors.Is(multi{}, errors.New("kakashi")))
+	assert.True(t, errors.Is(errors.Join(multi{notFound}), notFound))
+}
+
+func TestErrorAsTree(t *testing.T) {
+	err := multi{
+		fmt.Errorf("naruto"),
+		multi{nil, &errors.Error{Code: "SASUKE"}},
+		&errors.Error{Code: "SAKURA"},
+	}
+	var e *errors.Error
+	assert.True(t, errors.As(err, &e))
+	assert.Equal(t, "SASUKE", e.Code)
+	var m multi
+	assert.True(t, errors.As(errors.Join(errors.New("naruto"), multi{err}), &m))
+	assert.Len(t, m, 1)
+	assert.True(t, errors.As(errors.Join(err), &m))
+	assert.Len(t, m, 3)
+	var y struct{}
+	assert.False(t, errors.As(err, &y))
+	assert.False(t, errors.As(multi{}, &e))
+}
+
+var errSentinel = &errors.Error{StatusCode: http.StatusNotFound, Code: "NOT_FOUND", Message: "not found"}
+
+func TestErrorWrapSentinel(t *testing.T) {
+	w := errSentinel.Wrap(fmt.Errorf("naruto"))
+	assert.Nil(t, errSentinel.Details)
+	assert.Empty(t, errSentinel.Frames())
+	assert.True(t, errors.Is(w, errSentinel))
+	assert.True(t, errors.Is(fmt.Errorf("call: %w", w), errSentinel))
+	assert.True(t, errors.Is(w, &errors.Error{Code: "NOT_FOUND"}))
+	assert.False(t, errors.Is(w, &errors.Error{Code: "CONFLICT", Message: "not found"}))
+	assert.True(t, errors.Is(w, fmt.Errorf("naruto")))
+	assert.Equal(t, http.StatusNotFound, w.(*errors.Error).StatusCode)
+
+	var e *errors.Error
+	assert.True(t, errors.As(errors.Wrap(errSentinel, errors.Join(fmt.Errorf("boruto"), errSentinel)), &e))
+	assert.Equal(t, "NOT_FOUND", e.Code)
+}
+
+func TestErrorWrapConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	results := make([]error, 20)
+	for i := 0; i < len(results); i += 1 {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = errors.Wrap(errSentinel, fmt.Errorf("request %d", i))
+		}(i)
+	}
+	wg.Wait()
+	for i, err := range results {
+		assert.True(t, errors.Is(err, errSentinel))
+		assert.EqualError(t, errors.Unwrap(err), fmt.Sprintf("request %d", i))
+	}
+	assert.Nil(t, errSentinel.Details)
+}
+
+func TestErrorJSON(t *testing.T) {
+	b, err := json.Marshal(errSentinel.Wrap(fmt.Errorf("naruto")))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"code": "NOT_FOUND", "message": "not found", "details": {"code": "", "message": "naruto"}}`,
+		string(b))
+	b, err = json.Marshal(errSentinel.Wrap(errSentinel.WithMessage("boruto")))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"code": "NOT_FOUND", "message": "not found",
+		"details": {"code": "NOT_FOUND", "message": "boruto"}}`, string(b))
+	b, err = json.Marshal(errSentinel.WithDetails(map[string]int{"id": 42}))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"code": "NOT_FOUND", "message": "not found", "details": {"id": 42}}`, string(b))
+}
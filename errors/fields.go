@@ -0,0 +1,62 @@
+package errors
+
+// field is the key value pair of the context attached to the error
+type field struct {
+	key   string
+	value interface{}
+}
+
+// With is used to create a new error with the key value pair of the context attached, like the identifier of the
+// user or the name of the operation. The value is overridden if the key is already attached.
+func (e *Error) With(key string, value interface{}) *Error {
+	c := e.clone()
+	c.fields = make([]field, 0, len(e.fields)+1)
+	for _, f := range e.fields {
+		if f.key != key {
+			c.fields = append(c.fields, f)
+		}
+	}
+	c.fields = append(c.fields, field{key: key, value: value})
+	return c
+}
+
+// Fields is used to get the key value pairs of the context attached to the error and the errors in its chain.
+func (e *Error) Fields() map[string]interface{} {
+	return Fields(e)
+}
+
+// Fields is used to get the key value pairs of the context attached to the errors in the tree of the error,
+// following both the errors having an Unwrap() error method and the ones having an Unwrap() []error method.
+// If the same key is attached to many errors, the value attached to the outermost one is used.
+// It is nil if there are none.
+func Fields(err error) map[string]interface{} {
+	var fields map[string]interface{}
+	collectFields(err, &fields)
+	return fields
+}
+
+func collectFields(err error, fields *map[string]interface{}) {
+	for err != nil {
+		if e, ok := err.(*Error); ok && len(e.fields) > 0 {
+			if *fields == nil {
+				*fields = make(map[string]interface{}, len(e.fields))
+			}
+			for _, f := range e.fields {
+				if _, ok := (*fields)[f.key]; !ok {
+					(*fields)[f.key] = f.value
+				}
+			}
+		}
+		switch x := err.(type) {
+		case unwrap:
+			err = x.Unwrap()
+		case unwrapMulti:
+			for _, c := range x.Unwrap() {
+				collectFields(c, fields)
+			}
+			return
+		default:
+			return
+		}
+	}
+}
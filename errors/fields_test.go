@@ -0,0 +1,56 @@
+package errors_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/sinhashubham95/go-utils/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorWith(t *testing.T) {
+	assert.Nil(t, errSentinel.Fields())
+	e := errSentinel.With("user", 42).With("operation", "get").With("user", 43)
+	assert.Equal(t, map[string]interface{}{"user": 43, "operation": "get"}, e.Fields())
+	assert.Nil(t, errSentinel.Fields())
+	assert.Equal(t, "NOT_FOUND", e.Code)
+	assert.True(t, errors.Is(e, errSentinel))
+
+	f := e.With("order", 7)
+	assert.Equal(t, map[string]interface{}{"user": 43, "operation": "get"}, e.Fields())
+	assert.Equal(t, map[string]interface{}{"user": 43, "operation": "get", "order": 7}, f.Fields())
+}
+
+func TestFields(t *testing.T) {
+	assert.Nil(t, errors.Fields(nil))
+	assert.Nil(t, errors.Fields(fmt.Errorf("naruto")))
+
+	inner := errSentinel.With("user", 42).With("operation", "get")
+	outer := (&errors.Error{Code: "HANDLER"}).With("operation", "handle").With("request", "r-1").Wrap(
+		fmt.Errorf("call: %w", inner))
+	assert.Equal(t, map[string]interface{}{"user": 42, "operation": "handle", "request": "r-1"},
+		errors.Fields(fmt.Errorf("wrapped: %w", outer)))
+	assert.Equal(t, errors.Fields(outer), outer.(*errors.Error).Fields())
+
+	m := errors.Join(inner, (&errors.Error{Code: "OTHER"}).With("order", 7).With("user", 1))
+	assert.Equal(t, map[string]interface{}{"user": 42, "operation": "get", "order": 7}, errors.Fields(m))
+}
+
+func TestErrorWithConcurrent(t *testing.T) {
+	base := errSentinel.With("service", "users")
+	var wg sync.WaitGroup
+	results := make([]*errors.Error, 20)
+	for i := 0; i < len(results); i += 1 {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = base.With("request", i)
+		}(i)
+	}
+	wg.Wait()
+	for i, e := range results {
+		assert.Equal(t, map[string]interface{}{"service": "users", "request": i}, e.Fields())
+	}
+	assert.Equal(t, map[string]interface{}{"service": "users"}, base.Fields())
+}
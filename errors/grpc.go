@@ -0,0 +1,147 @@
+package errors
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// statusClientClosedRequest is the non-standard HTTP status code used when the client cancels the request.
+const statusClientClosedRequest = 499
+
+// GRPCCode is the gRPC status code of the error.
+// The values are the same as the ones of google.golang.org/grpc/codes, so they can be converted directly.
+type GRPCCode uint32
+
+// gRPC status codes
+const (
+	GRPCOK                 GRPCCode = 0
+	GRPCCanceled           GRPCCode = 1
+	GRPCUnknown            GRPCCode = 2
+	GRPCInvalidArgument    GRPCCode = 3
+	GRPCDeadlineExceeded   GRPCCode = 4
+	GRPCNotFound           GRPCCode = 5
+	GRPCAlreadyExists      GRPCCode = 6
+	GRPCPermissionDenied   GRPCCode = 7
+	GRPCResourceExhausted  GRPCCode = 8
+	GRPCFailedPrecondition GRPCCode = 9
+	GRPCAborted            GRPCCode = 10
+	GRPCOutOfRange         GRPCCode = 11
+	GRPCUnimplemented      GRPCCode = 12
+	GRPCInternal           GRPCCode = 13
+	GRPCUnavailable        GRPCCode = 14
+	GRPCDataLoss           GRPCCode = 15
+	GRPCUnauthenticated    GRPCCode = 16
+)
+
+var grpcCodeNames = [...]string{
+	GRPCOK:                 "OK",
+	GRPCCanceled:           "Canceled",
+	GRPCUnknown:            "Unknown",
+	GRPCInvalidArgument:    "InvalidArgument",
+	GRPCDeadlineExceeded:   "DeadlineExceeded",
+	GRPCNotFound:           "NotFound",
+	GRPCAlreadyExists:      "AlreadyExists",
+	GRPCPermissionDenied:   "PermissionDenied",
+	GRPCResourceExhausted:  "ResourceExhausted",
+	GRPCFailedPrecondition: "FailedPrecondition",
+	GRPCAborted:            "Aborted",
+	GRPCOutOfRange:         "OutOfRange",
+	GRPCUnimplemented:      "Unimplemented",
+	GRPCInternal:           "Internal",
+	GRPCUnavailable:        "Unavailable",
+	GRPCDataLoss:           "DataLoss",
+	GRPCUnauthenticated:    "Unauthenticated",
+}
+
+// String returns the name of the code, the same as the one used by gRPC.
+func (c GRPCCode) String() string {
+	if int(c) < len(grpcCodeNames) {
+		return grpcCodeNames[c]
+	}
+	return "Code(" + strconv.FormatUint(uint64(c), 10) + ")"
+}
+
+// GRPCCodeFromHTTPStatus is used to get the gRPC status code corresponding to the HTTP status code.
+// The status codes without a direct counterpart are mapped by their class, so the other 4xx are FailedPrecondition,
+// the other 5xx are Internal and the others are Unknown.
+func GRPCCodeFromHTTPStatus(status int) GRPCCode {
+	switch status {
+	case http.StatusBadRequest:
+		return GRPCInvalidArgument
+	case http.StatusUnauthorized:
+		return GRPCUnauthenticated
+	case http.StatusForbidden:
+		return GRPCPermissionDenied
+	case http.StatusNotFound:
+		return GRPCNotFound
+	case http.StatusRequestTimeout, http.StatusGatewayTimeout:
+		return GRPCDeadlineExceeded
+	case http.StatusConflict:
+		return GRPCAlreadyExists
+	case http.StatusPreconditionFailed:
+		return GRPCFailedPrecondition
+	case http.StatusRequestedRangeNotSatisfiable:
+		return GRPCOutOfRange
+	case http.StatusTooManyRequests:
+		return GRPCResourceExhausted
+	case statusClientClosedRequest:
+		return GRPCCanceled
+	case http.StatusNotImplemented:
+		return GRPCUnimplemented
+	case http.StatusServiceUnavailable:
+		return GRPCUnavailable
+	}
+	switch {
+	case status >= 200 && status < 300:
+		return GRPCOK
+	case status >= 400 && status < 500:
+		return GRPCFailedPrecondition
+	case status >= 500 && status < 600:
+		return GRPCInternal
+	default:
+		return GRPCUnknown
+	}
+}
+
+// HTTPStatusFromGRPCCode is used to get the HTTP status code corresponding to the gRPC status code.
+// The mapping is the same as the one used by the gRPC gateway, and the unknown codes are 500.
+func HTTPStatusFromGRPCCode(code GRPCCode) int {
+	switch code {
+	case GRPCOK:
+		return http.StatusOK
+	case GRPCCanceled:
+		return statusClientClosedRequest
+	case GRPCInvalidArgument, GRPCFailedPrecondition, GRPCOutOfRange:
+		return http.StatusBadRequest
+	case GRPCDeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case GRPCNotFound:
+		return http.StatusNotFound
+	case GRPCAlreadyExists, GRPCAborted:
+		return http.StatusConflict
+	case GRPCPermissionDenied:
+		return http.StatusForbidden
+	case GRPCUnauthenticated:
+		return http.StatusUnauthorized
+	case GRPCResourceExhausted:
+		return http.StatusTooManyRequests
+	case GRPCUnimplemented:
+		return http.StatusNotImplemented
+	case GRPCUnavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// GRPCStatusCode returns the gRPC status code of the error,
+// which is the one set explicitly or the one corresponding to its HTTP status code.
+func (e *Error) GRPCStatusCode() GRPCCode {
+	if e.GRPCCode != GRPCOK {
+		return e.GRPCCode
+	}
+	if e.StatusCode == 0 {
+		return GRPCUnknown
+	}
+	return GRPCCodeFromHTTPStatus(e.StatusCode)
+}
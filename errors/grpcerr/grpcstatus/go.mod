@@ -0,0 +1,25 @@
+module github.com/sinhashubham95/go-utils/errors/grpcerr/grpcstatus
+
+go 1.18
+
+require (
+	github.com/sinhashubham95/go-utils v1.0.0
+	github.com/stretchr/testify v1.8.2
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20230711160842-782d3b101e98
+	google.golang.org/grpc v1.57.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/golang/protobuf v1.5.3 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/net v0.9.0 // indirect
+	golang.org/x/sys v0.13.0 // indirect
+	golang.org/x/text v0.9.0 // indirect
+	google.golang.org/protobuf v1.31.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// the root module is used from this repository while developing, and the release workflow requires the version
+// released along with this module, which is the one used by the other modules importing it
+replace github.com/sinhashubham95/go-utils => ../../..
@@ -0,0 +1,120 @@
+// Package grpcstatus is used to exchange the errors over gRPC using google.golang.org/grpc, converting them to and
+// from the gRPC status with the code and the details of the error in a google.rpc.ErrorInfo detail.
+//
+// It is a separate module, so that the other packages of this library stay free of the gRPC dependency.
+// The servers and the clients use the interceptors, for example
+//
+//	server := grpc.NewServer(
+//		grpc.ChainUnaryInterceptor(grpcstatus.UnaryServerInterceptor("users.example.com")),
+//		grpc.ChainStreamInterceptor(grpcstatus.StreamServerInterceptor("users.example.com")),
+//	)
+//	conn, err := grpc.Dial(target,
+//		grpc.WithChainUnaryInterceptor(grpcstatus.UnaryClientInterceptor()),
+//		grpc.WithChainStreamInterceptor(grpcstatus.StreamClientInterceptor()),
+//	)
+package grpcstatus
+
+import (
+	"github.com/sinhashubham95/go-utils/errors"
+	"github.com/sinhashubham95/go-utils/errors/grpcerr"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ToStatus is used to convert the error to the gRPC status, with the given domain in the error info, the same as
+// grpcerr.ToStatus. It is nil if the error is nil.
+func ToStatus(err error, domain string) *status.Status {
+	return New(grpcerr.ToStatus(err, domain))
+}
+
+// New is used to create the gRPC status from the status, with the error info as its detail.
+// It is nil if the status is nil.
+func New(s *grpcerr.Status) *status.Status {
+	if s == nil {
+		return nil
+	}
+	st := status.New(codes.Code(s.Code), s.Message)
+	if s.Info == nil {
+		return st
+	}
+	// the details cannot be added to the status with the code OK
+	if d, err := st.WithDetails(&errdetails.ErrorInfo{Reason: s.Info.Reason, Domain: s.Info.Domain,
+		Metadata: s.Info.Metadata}); err == nil {
+		st = d
+	}
+	return st
+}
+
+// FromStatus is used to create the status from the gRPC status, with the error info from its first
+// google.rpc.ErrorInfo detail. It is nil if the gRPC status is nil.
+func FromStatus(st *status.Status) *grpcerr.Status {
+	if st == nil {
+		return nil
+	}
+	s := &grpcerr.Status{Code: errors.GRPCCode(st.Code()), Message: st.Message()}
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok {
+			s.Info = &grpcerr.ErrorInfo{Reason: info.Reason, Domain: info.Domain, Metadata: info.Metadata}
+			break
+		}
+	}
+	return s
+}
+
+// Error is used to convert the error to the gRPC status error, with the given domain in the error info, the same as
+// grpcerr.ToStatus. It is nil if the error is nil.
+func Error(err error, domain string) error {
+	return ToStatus(err, domain).Err()
+}
+
+// FromError is used to convert the gRPC status error, or the error wrapping it, to the error, the same as
+// grpcerr.Status.Error. It is nil if the error does not have a gRPC status or has the code OK.
+func FromError(err error) *errors.Error {
+	return fromStatus(err).Error()
+}
+
+// UnaryServerInterceptor is used to create the unary server interceptor converting the errors returned by the
+// handlers to the gRPC status errors, with the given domain in the error info. Only the errors having an
+// *errors.Error or an *errors.MultiError in the chain are converted, the others are returned as they are.
+func UnaryServerInterceptor(domain string) grpc.UnaryServerInterceptor {
+	return grpc.UnaryServerInterceptor(grpcerr.UnaryServerInterceptor[*grpc.UnaryServerInfo, grpc.UnaryHandler](
+		toError, domain))
+}
+
+// StreamServerInterceptor is used to create the stream server interceptor converting the errors returned by the
+// handlers to the gRPC status errors, the same as UnaryServerInterceptor.
+func StreamServerInterceptor(domain string) grpc.StreamServerInterceptor {
+	return grpc.StreamServerInterceptor(grpcerr.StreamServerInterceptor[grpc.ServerStream, *grpc.StreamServerInfo,
+		grpc.StreamHandler](toError, domain))
+}
+
+// UnaryClientInterceptor is used to create the unary client interceptor converting the gRPC status errors returned
+// by the calls to *errors.Error. The other errors are returned as they are.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return grpc.UnaryClientInterceptor(grpcerr.UnaryClientInterceptor[*grpc.ClientConn, grpc.UnaryInvoker,
+		grpc.CallOption](fromStatus))
+}
+
+// StreamClientInterceptor is used to create the stream client interceptor converting the gRPC status errors
+// returned while creating the streams to *errors.Error, the same as UnaryClientInterceptor. The errors received
+// on the streams are not converted, and can be converted using FromError.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return grpc.StreamClientInterceptor(grpcerr.StreamClientInterceptor[*grpc.StreamDesc, *grpc.ClientConn,
+		grpc.ClientStream, grpc.Streamer, grpc.CallOption](fromStatus))
+}
+
+func toError(s *grpcerr.Status) error {
+	return New(s).Err()
+}
+
+// fromStatus returns the status of the gRPC status error in the chain of the error, with its own message unlike
+// status.FromError, which has the message of the whole error when the status error is wrapped.
+func fromStatus(err error) *grpcerr.Status {
+	var s interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &s) {
+		return nil
+	}
+	return FromStatus(s.GRPCStatus())
+}
@@ -0,0 +1,145 @@
+package grpcstatus_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/sinhashubham95/go-utils/errors"
+	"github.com/sinhashubham95/go-utils/errors/grpcerr/grpcstatus"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+var errUserNotFound = &errors.Error{StatusCode: http.StatusNotFound, Code: "USER_NOT_FOUND",
+	Message: "user {id} not found"}
+
+// healthServer is the health service failing with the error of the service checked.
+type healthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+	errors map[string]error
+}
+
+func (s *healthServer) Check(_ context.Context,
+	r *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	if err := s.errors[r.Service]; err != nil {
+		return nil, err
+	}
+	return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}, nil
+}
+
+func (s *healthServer) Watch(r *grpc_health_v1.HealthCheckRequest, stream grpc_health_v1.Health_WatchServer) error {
+	if err := s.errors[r.Service]; err != nil {
+		return err
+	}
+	return stream.Send(&grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING})
+}
+
+func dial(t *testing.T, errs map[string]error, opts ...grpc.DialOption) *grpc.ClientConn {
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(grpcstatus.UnaryServerInterceptor("users.example.com")),
+		grpc.ChainStreamInterceptor(grpcstatus.StreamServerInterceptor("users.example.com")),
+	)
+	grpc_health_v1.RegisterHealthServer(server, &healthServer{errors: errs})
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}))
+	conn, err := grpc.Dial("bufnet", opts...)
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+func TestToStatus(t *testing.T) {
+	st := grpcstatus.ToStatus(fmt.Errorf("get: %w", errUserNotFound.WithParam("id", 42).
+		Wrap(fmt.Errorf("secret internals"))), "users.example.com")
+	assert.Equal(t, codes.NotFound, st.Code())
+	assert.Equal(t, "user 42 not found", st.Message())
+	assert.Len(t, st.Details(), 1)
+	info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	assert.True(t, ok)
+	assert.Equal(t, "USER_NOT_FOUND", info.Reason)
+	assert.Equal(t, "users.example.com", info.Domain)
+	assert.Equal(t, map[string]string{"http_status": "404", "params": `{"id":42}`}, info.Metadata)
+
+	assert.Equal(t, codes.Unknown, grpcstatus.ToStatus(fmt.Errorf("naruto"), "").Code())
+	assert.Empty(t, grpcstatus.ToStatus(fmt.Errorf("naruto"), "").Details())
+	assert.Nil(t, grpcstatus.ToStatus(nil, ""))
+	assert.NoError(t, grpcstatus.Error(nil, ""))
+}
+
+func TestFromError(t *testing.T) {
+	e := grpcstatus.FromError(fmt.Errorf("call: %w", grpcstatus.Error(errUserNotFound.WithParam("id", 42), "")))
+	assert.Equal(t, &errors.Error{StatusCode: http.StatusNotFound, Code: "USER_NOT_FOUND", Message: "user 42 not found",
+		Params: errors.Params{"id": 42.0}, GRPCCode: errors.GRPCNotFound}, e)
+
+	e = grpcstatus.FromError(status.Error(codes.Unavailable, "naruto"))
+	assert.Equal(t, &errors.Error{StatusCode: http.StatusServiceUnavailable, Message: "naruto",
+		GRPCCode: errors.GRPCUnavailable}, e)
+
+	assert.Nil(t, grpcstatus.FromError(nil))
+	assert.Nil(t, grpcstatus.FromError(fmt.Errorf("naruto")))
+}
+
+func TestInterceptors(t *testing.T) {
+	conn := dial(t, map[string]error{
+		"users":  fmt.Errorf("get: %w", errUserNotFound.WithParam("id", 42).Wrap(fmt.Errorf("secret internals"))),
+		"orders": fmt.Errorf("secret internals"),
+	}, grpc.WithChainUnaryInterceptor(grpcstatus.UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(grpcstatus.StreamClientInterceptor()))
+	client := grpc_health_v1.NewHealthClient(conn)
+	ctx := context.Background()
+
+	resp, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, resp.Status)
+
+	_, err = client.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: "users"})
+	var e *errors.Error
+	assert.True(t, errors.As(err, &e))
+	assert.Equal(t, &errors.Error{StatusCode: http.StatusNotFound, Code: "USER_NOT_FOUND", Message: "user 42 not found",
+		Params: errors.Params{"id": 42.0}, GRPCCode: errors.GRPCNotFound}, e)
+	assert.True(t, errors.Is(err, errUserNotFound))
+	assert.NotContains(t, fmt.Sprint(e.Details), "secret")
+
+	// the errors without an *errors.Error are left to gRPC
+	_, err = client.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: "orders"})
+	assert.Equal(t, &errors.Error{StatusCode: http.StatusInternalServerError, Message: "secret internals",
+		GRPCCode: errors.GRPCUnknown}, err)
+
+	stream, err := client.Watch(ctx, &grpc_health_v1.HealthCheckRequest{Service: "users"})
+	assert.NoError(t, err)
+	_, err = stream.Recv()
+	e = grpcstatus.FromError(err)
+	assert.Equal(t, "USER_NOT_FOUND", e.Code)
+	assert.Equal(t, http.StatusNotFound, e.StatusCode)
+}
+
+func TestServerInterceptorsRaw(t *testing.T) {
+	conn := dial(t, map[string]error{"users": errUserNotFound.WithParam("id", 42)})
+	client := grpc_health_v1.NewHealthClient(conn)
+
+	_, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: "users"})
+	st, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.NotFound, st.Code())
+	assert.Equal(t, "user 42 not found", st.Message())
+	assert.Len(t, st.Details(), 1)
+	info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	assert.True(t, ok)
+	assert.Equal(t, "USER_NOT_FOUND", info.Reason)
+	assert.Equal(t, "users.example.com", info.Domain)
+}
@@ -0,0 +1,85 @@
+package grpcerr
+
+import (
+	"context"
+
+	"github.com/sinhashubham95/go-utils/errors"
+)
+
+// UnaryServerInterceptor is used to create the unary server interceptor converting the errors returned by the
+// handlers to the gRPC status errors, using toError to create them from the status. Only the errors having an
+// *errors.Error or an *errors.MultiError in the chain are converted, the others are returned as they are.
+//
+// It is instantiated with the gRPC types, grpcerr.UnaryServerInterceptor[*grpc.UnaryServerInfo, grpc.UnaryHandler],
+// making it a grpc.UnaryServerInterceptor.
+func UnaryServerInterceptor[Info any, Handler ~func(context.Context, interface{}) (interface{}, error)](
+	toError func(*Status) error, domain string) func(context.Context, interface{}, Info, Handler) (interface{}, error) {
+	return func(ctx context.Context, req interface{}, _ Info, handler Handler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		return resp, serverError(err, toError, domain)
+	}
+}
+
+// StreamServerInterceptor is used to create the stream server interceptor converting the errors returned by the
+// handlers to the gRPC status errors, the same as UnaryServerInterceptor.
+//
+// It is instantiated with the gRPC types,
+// grpcerr.StreamServerInterceptor[grpc.ServerStream, *grpc.StreamServerInfo, grpc.StreamHandler],
+// making it a grpc.StreamServerInterceptor.
+func StreamServerInterceptor[Stream any, Info any, Handler ~func(interface{}, Stream) error](
+	toError func(*Status) error, domain string) func(interface{}, Stream, Info, Handler) error {
+	return func(srv interface{}, stream Stream, _ Info, handler Handler) error {
+		return serverError(handler(srv, stream), toError, domain)
+	}
+}
+
+// UnaryClientInterceptor is used to create the unary client interceptor converting the gRPC status errors returned
+// by the calls to *errors.Error, using fromStatus to get the status from them. The errors for which fromStatus
+// returns nil are returned as they are.
+//
+// It is instantiated with the gRPC types,
+// grpcerr.UnaryClientInterceptor[*grpc.ClientConn, grpc.UnaryInvoker, grpc.CallOption],
+// making it a grpc.UnaryClientInterceptor.
+func UnaryClientInterceptor[Conn any, Invoker ~func(context.Context, string, interface{}, interface{}, Conn,
+	...Option) error, Option any](fromStatus func(error) *Status) func(context.Context, string, interface{},
+	interface{}, Conn, Invoker, ...Option) error {
+	return func(ctx context.Context, method string, req, reply interface{}, conn Conn, invoker Invoker,
+		opts ...Option) error {
+		return clientError(invoker(ctx, method, req, reply, conn, opts...), fromStatus)
+	}
+}
+
+// StreamClientInterceptor is used to create the stream client interceptor converting the gRPC status errors
+// returned while creating the streams to *errors.Error, the same as UnaryClientInterceptor.
+//
+// It is instantiated with the gRPC types, grpcerr.StreamClientInterceptor[*grpc.StreamDesc, *grpc.ClientConn,
+// grpc.ClientStream, grpc.Streamer, grpc.CallOption], making it a grpc.StreamClientInterceptor.
+func StreamClientInterceptor[Desc any, Conn any, Stream any, Streamer ~func(context.Context, Desc, Conn, string,
+	...Option) (Stream, error), Option any](fromStatus func(error) *Status) func(context.Context, Desc, Conn, string,
+	Streamer, ...Option) (Stream, error) {
+	return func(ctx context.Context, desc Desc, conn Conn, method string, streamer Streamer,
+		opts ...Option) (Stream, error) {
+		stream, err := streamer(ctx, desc, conn, method, opts...)
+		return stream, clientError(err, fromStatus)
+	}
+}
+
+func serverError(err error, toError func(*Status) error, domain string) error {
+	var e *errors.Error
+	var m *errors.MultiError
+	if err == nil || !errors.As(err, &e) && !errors.As(err, &m) {
+		return err
+	}
+	return toError(ToStatus(err, domain))
+}
+
+func clientError(err error, fromStatus func(error) *Status) error {
+	if err == nil {
+		return nil
+	}
+	s := fromStatus(err)
+	if s == nil || s.Code == errors.GRPCOK {
+		return err
+	}
+	return s.Error()
+}
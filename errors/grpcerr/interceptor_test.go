@@ -0,0 +1,179 @@
+package grpcerr_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/sinhashubham95/go-utils/errors"
+	"github.com/sinhashubham95/go-utils/errors/grpcerr"
+	"github.com/stretchr/testify/assert"
+)
+
+// the types below have the same shape as the ones of google.golang.org/grpc
+
+type unaryServerInfo struct {
+	FullMethod string
+}
+
+type unaryHandler func(ctx context.Context, req interface{}) (interface{}, error)
+
+type unaryServerInterceptor func(ctx context.Context, req interface{}, info *unaryServerInfo,
+	handler unaryHandler) (interface{}, error)
+
+type serverStream interface {
+	Context() context.Context
+}
+
+type streamServerInfo struct {
+	FullMethod string
+}
+
+type streamHandler func(srv interface{}, stream serverStream) error
+
+type streamServerInterceptor func(srv interface{}, ss serverStream, info *streamServerInfo,
+	handler streamHandler) error
+
+type clientConn struct{}
+
+type callOption interface{}
+
+type unaryInvoker func(ctx context.Context, method string, req, reply interface{}, cc *clientConn,
+	opts ...callOption) error
+
+type unaryClientInterceptor func(ctx context.Context, method string, req, reply interface{}, cc *clientConn,
+	invoker unaryInvoker, opts ...callOption) error
+
+type streamDesc struct{}
+
+type clientStream interface{}
+
+type streamer func(ctx context.Context, desc *streamDesc, cc *clientConn, method string,
+	opts ...callOption) (clientStream, error)
+
+type streamClientInterceptor func(ctx context.Context, desc *streamDesc, cc *clientConn, method string,
+	streamer streamer, opts ...callOption) (clientStream, error)
+
+// statusError is the error carrying the status, like the one of google.golang.org/grpc/status
+type statusError struct {
+	s *grpcerr.Status
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("rpc error: code = %s desc = %s", e.s.Code, e.s.Message)
+}
+
+func toError(s *grpcerr.Status) error {
+	return &statusError{s: s}
+}
+
+func fromStatus(err error) *grpcerr.Status {
+	if e, ok := err.(*statusError); ok {
+		return e.s
+	}
+	return nil
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	var i unaryServerInterceptor = grpcerr.UnaryServerInterceptor[*unaryServerInfo, unaryHandler](toError,
+		"users.example.com")
+	info := &unaryServerInfo{FullMethod: "/users.Users/Get"}
+
+	resp, err := i(context.Background(), "naruto", info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return req, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "naruto", resp)
+
+	_, err = i(context.Background(), "naruto", info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, errUserNotFound.WithParam("id", 42)
+	})
+	assert.Equal(t, "rpc error: code = NotFound desc = user 42 not found", err.Error())
+	assert.Equal(t, "USER_NOT_FOUND", err.(*statusError).s.Info.Reason)
+	assert.Equal(t, "users.example.com", err.(*statusError).s.Info.Domain)
+
+	plain := fmt.Errorf("naruto")
+	_, err = i(context.Background(), "naruto", info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, plain
+	})
+	assert.Equal(t, plain, err)
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	var i streamServerInterceptor = grpcerr.StreamServerInterceptor[serverStream, *streamServerInfo, streamHandler](
+		toError, "")
+	info := &streamServerInfo{FullMethod: "/users.Users/List"}
+
+	assert.NoError(t, i(nil, nil, info, func(srv interface{}, stream serverStream) error {
+		return nil
+	}))
+	err := i(nil, nil, info, func(srv interface{}, stream serverStream) error {
+		return errors.Join(errUserNotFound, &errors.Error{StatusCode: http.StatusBadRequest})
+	})
+	assert.Equal(t, errors.GRPCNotFound, err.(*statusError).s.Code)
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	var i unaryClientInterceptor = grpcerr.UnaryClientInterceptor[*clientConn, unaryInvoker, callOption](fromStatus)
+	var server unaryServerInterceptor = grpcerr.UnaryServerInterceptor[*unaryServerInfo, unaryHandler](toError, "")
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *clientConn,
+		opts ...callOption) error {
+		assert.Equal(t, "/users.Users/Get", method)
+		assert.Len(t, opts, 1)
+		_, err := server(ctx, req, &unaryServerInfo{FullMethod: method},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				if req == nil {
+					return nil, nil
+				}
+				if s, ok := req.(string); ok {
+					return nil, fmt.Errorf("%s", s)
+				}
+				return nil, req.(error)
+			})
+		return err
+	}
+
+	assert.NoError(t, i(context.Background(), "/users.Users/Get", nil, nil, &clientConn{}, invoker, "naruto"))
+
+	err := i(context.Background(), "/users.Users/Get", errUserNotFound.WithParam("id", 42).WithRetryable(true), nil,
+		&clientConn{}, invoker, "naruto")
+	e, ok := err.(*errors.Error)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusNotFound, e.StatusCode)
+	assert.Equal(t, "USER_NOT_FOUND", e.Code)
+	assert.Equal(t, "user 42 not found", e.Message)
+	assert.True(t, e.Retryable)
+	assert.True(t, errors.Is(err, errUserNotFound))
+
+	err = i(context.Background(), "/users.Users/Get", "naruto", nil, &clientConn{}, invoker, "naruto")
+	assert.EqualError(t, err, "naruto")
+}
+
+func TestStreamClientInterceptor(t *testing.T) {
+	var i streamClientInterceptor = grpcerr.StreamClientInterceptor[*streamDesc, *clientConn, clientStream, streamer,
+		callOption](fromStatus)
+
+	stream, err := i(context.Background(), &streamDesc{}, &clientConn{}, "/users.Users/List",
+		func(ctx context.Context, desc *streamDesc, cc *clientConn, method string,
+			opts ...callOption) (clientStream, error) {
+			return "naruto", nil
+		})
+	assert.NoError(t, err)
+	assert.Equal(t, "naruto", stream)
+
+	_, err = i(context.Background(), &streamDesc{}, &clientConn{}, "/users.Users/List",
+		func(ctx context.Context, desc *streamDesc, cc *clientConn, method string,
+			opts ...callOption) (clientStream, error) {
+			return nil, toError(&grpcerr.Status{Code: errors.GRPCUnavailable, Message: "naruto"})
+		})
+	assert.Equal(t, &errors.Error{StatusCode: http.StatusServiceUnavailable, Message: "naruto",
+		GRPCCode: errors.GRPCUnavailable}, err)
+
+	_, err = i(context.Background(), &streamDesc{}, &clientConn{}, "/users.Users/List",
+		func(ctx context.Context, desc *streamDesc, cc *clientConn, method string,
+			opts ...callOption) (clientStream, error) {
+			return nil, toError(&grpcerr.Status{Code: errors.GRPCOK})
+		})
+	assert.IsType(t, &statusError{}, err)
+}
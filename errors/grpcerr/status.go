@@ -0,0 +1,183 @@
+// Package grpcerr is used to exchange the errors over gRPC.
+//
+// The errors are converted to and from Status, which has the same shape as google.rpc.Status, with the code and
+// the details of the error in a google.rpc.ErrorInfo detail. To keep this library free of the gRPC dependency,
+// the conversion between Status and the gRPC status is done by the separate module
+// github.com/sinhashubham95/go-utils/errors/grpcerr/grpcstatus, which also has the interceptors for the gRPC
+// servers and clients. The interceptors here are generic over the gRPC types, so that they can be used with any
+// conversion, for example
+//
+//	grpcerr.UnaryServerInterceptor[*grpc.UnaryServerInfo, grpc.UnaryHandler](toError, "users.example.com")
+package grpcerr
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/sinhashubham95/go-utils/errors"
+)
+
+// ErrorInfoType is the type URL of the google.rpc.ErrorInfo detail.
+const ErrorInfoType = "type.googleapis.com/google.rpc.ErrorInfo"
+
+// keys of the metadata of the error info
+const (
+	StatusCodeKey = "http_status"
+	DetailsKey    = "details"
+	ParamsKey     = "params"
+	RetryableKey  = "retryable"
+	SeverityKey   = "severity"
+)
+
+// Status is the gRPC status of an error, having the same shape as google.rpc.Status.
+type Status struct {
+	Code    errors.GRPCCode
+	Message string
+	// Info is the google.rpc.ErrorInfo detail of the status, holding the code and the details of the error.
+	Info *ErrorInfo
+}
+
+// ErrorInfo is the detail of the status describing the cause of the error, having the same shape as
+// google.rpc.ErrorInfo.
+type ErrorInfo struct {
+	// Reason is the code of the error.
+	Reason string `json:"reason"`
+	// Domain is the logical grouping to which the reason belongs, usually the name of the service.
+	Domain string `json:"domain,omitempty"`
+	// Metadata is the additional structured details of the error, with the values encoded in JSON.
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// ToStatus is used to convert the error to the status, with the given domain in the error info.
+//
+// The status is created from the outermost *errors.Error or *errors.MultiError in the chain of the error. For an
+// *errors.Error, the status has its gRPC status code, its rendered message and the error info with its code, HTTP
+// status code, details, params, retryable flag and severity. The details which are errors, like the causes wrapped
+// using Wrap, are never included, since they can hold the internals. For an *errors.MultiError, the status has the
+// code corresponding to its HTTP status code. The context errors have the codes Canceled and DeadlineExceeded, and
+// the other errors are Unknown, as gRPC does. It is nil if the error is nil.
+func ToStatus(err error, domain string) *Status {
+	if err == nil {
+		return nil
+	}
+	e, m := errors.Outermost(err)
+	switch {
+	case e != nil:
+		return &Status{Code: e.GRPCStatusCode(), Message: e.RenderMessage(), Info: errorInfo(e, domain)}
+	case m != nil:
+		s := &Status{Code: errors.GRPCCodeFromHTTPStatus(m.StatusCode()), Message: m.Error()}
+		if m.Code != "" {
+			s.Info = &ErrorInfo{Reason: m.Code, Domain: domain,
+				Metadata: map[string]string{StatusCodeKey: strconv.Itoa(m.StatusCode())}}
+		}
+		return s
+	case errors.Is(err, context.Canceled):
+		return &Status{Code: errors.GRPCCanceled, Message: err.Error()}
+	case errors.Is(err, context.DeadlineExceeded):
+		return &Status{Code: errors.GRPCDeadlineExceeded, Message: err.Error()}
+	default:
+		return &Status{Code: errors.GRPCUnknown, Message: err.Error()}
+	}
+}
+
+// Error is used to convert the status to the error.
+//
+// The code, HTTP status code, details, params, retryable flag and severity come from the error info, and the HTTP
+// status code is the one corresponding to the gRPC status code if the error info does not have it.
+// It is nil if the status is nil or has the code OK.
+func (s *Status) Error() *errors.Error {
+	if s == nil || s.Code == errors.GRPCOK {
+		return nil
+	}
+	e := &errors.Error{
+		StatusCode: errors.HTTPStatusFromGRPCCode(s.Code),
+		Message:    s.Message,
+		GRPCCode:   s.Code,
+	}
+	if s.Info == nil {
+		return e
+	}
+	e.Code = s.Info.Reason
+	if v, err := strconv.Atoi(s.Info.Metadata[StatusCodeKey]); err == nil {
+		e.StatusCode = v
+	}
+	if v, ok := s.Info.Metadata[DetailsKey]; ok {
+		_ = json.Unmarshal([]byte(v), &e.Details)
+	}
+	if v, ok := s.Info.Metadata[ParamsKey]; ok {
+		_ = json.Unmarshal([]byte(v), &e.Params)
+	}
+	e.Retryable = s.Info.Metadata[RetryableKey] == "true"
+	e.Severity = errors.Severity(s.Info.Metadata[SeverityKey])
+	return e
+}
+
+// MarshalJSON is used to render the status the same as google.rpc.Status is rendered in JSON.
+func (s *Status) MarshalJSON() ([]byte, error) {
+	type detail struct {
+		Type string `json:"@type"`
+		*ErrorInfo
+	}
+	v := struct {
+		Code    errors.GRPCCode `json:"code"`
+		Message string          `json:"message,omitempty"`
+		Details []detail        `json:"details,omitempty"`
+	}{Code: s.Code, Message: s.Message}
+	if s.Info != nil {
+		v.Details = []detail{{Type: ErrorInfoType, ErrorInfo: s.Info}}
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON is used to parse the status rendered the same as google.rpc.Status is rendered in JSON.
+// The details other than google.rpc.ErrorInfo are ignored.
+func (s *Status) UnmarshalJSON(b []byte) error {
+	var v struct {
+		Code    errors.GRPCCode   `json:"code"`
+		Message string            `json:"message"`
+		Details []json.RawMessage `json:"details"`
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*s = Status{Code: v.Code, Message: v.Message}
+	for _, d := range v.Details {
+		var info struct {
+			Type string `json:"@type"`
+			ErrorInfo
+		}
+		if err := json.Unmarshal(d, &info); err != nil {
+			return err
+		}
+		if info.Type == ErrorInfoType {
+			s.Info = &info.ErrorInfo
+			break
+		}
+	}
+	return nil
+}
+
+func errorInfo(e *errors.Error, domain string) *ErrorInfo {
+	info := &ErrorInfo{Reason: e.Code, Domain: domain, Metadata: map[string]string{}}
+	if e.StatusCode != 0 {
+		info.Metadata[StatusCodeKey] = strconv.Itoa(e.StatusCode)
+	}
+	if _, ok := e.Details.(error); !ok && e.Details != nil {
+		if b, err := json.Marshal(e.Details); err == nil {
+			info.Metadata[DetailsKey] = string(b)
+		}
+	}
+	if len(e.Params) > 0 {
+		if b, err := json.Marshal(e.Params); err == nil {
+			info.Metadata[ParamsKey] = string(b)
+		}
+	}
+	if e.Retryable {
+		info.Metadata[RetryableKey] = "true"
+	}
+	if e.Severity != "" {
+		info.Metadata[SeverityKey] = string(e.Severity)
+	}
+	return info
+}
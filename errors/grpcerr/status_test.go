@@ -0,0 +1,124 @@
+package grpcerr_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/sinhashubham95/go-utils/errors"
+	"github.com/sinhashubham95/go-utils/errors/grpcerr"
+	"github.com/stretchr/testify/assert"
+)
+
+var errUserNotFound = &errors.Error{StatusCode: http.StatusNotFound, Code: "USER_NOT_FOUND",
+	Message: "user {id} not found"}
+
+func TestToStatus(t *testing.T) {
+	assert.Nil(t, grpcerr.ToStatus(nil, "naruto"))
+
+	s := grpcerr.ToStatus(fmt.Errorf("wrapped: %w", errUserNotFound.WithParam("id", 42).
+		WithDetails(map[string]interface{}{"team": 7}).WithRetryable(true).WithSeverity(errors.SeverityWarning)),
+		"users.example.com")
+	assert.Equal(t, &grpcerr.Status{
+		Code:    errors.GRPCNotFound,
+		Message: "user 42 not found",
+		Info: &grpcerr.ErrorInfo{
+			Reason: "USER_NOT_FOUND",
+			Domain: "users.example.com",
+			Metadata: map[string]string{
+				grpcerr.StatusCodeKey: "404",
+				grpcerr.DetailsKey:    `{"team":7}`,
+				grpcerr.ParamsKey:     `{"id":42}`,
+				grpcerr.RetryableKey:  "true",
+				grpcerr.SeverityKey:   "warning",
+			},
+		},
+	}, s)
+
+	s = grpcerr.ToStatus(errUserNotFound.WithGRPCCode(errors.GRPCFailedPrecondition), "")
+	assert.Equal(t, errors.GRPCFailedPrecondition, s.Code)
+
+	s = grpcerr.ToStatus(errors.Join(errUserNotFound, fmt.Errorf("naruto")).(*errors.MultiError).WithCode("BATCH"), "")
+	assert.Equal(t, errors.GRPCInternal, s.Code)
+	assert.Equal(t, "BATCH", s.Info.Reason)
+	assert.Equal(t, "500", s.Info.Metadata[grpcerr.StatusCodeKey])
+	assert.Nil(t, grpcerr.ToStatus(errors.Join(errUserNotFound), "").Info)
+
+	// the outermost error is used, and the causes wrapped are never included
+	invalid := &errors.Error{StatusCode: http.StatusUnprocessableEntity, Code: "VALIDATION"}
+	s = grpcerr.ToStatus(fmt.Errorf("create: %w", invalid.Wrap(errors.Join(errUserNotFound))), "")
+	assert.Equal(t, errors.GRPCFailedPrecondition, s.Code)
+	assert.Equal(t, "VALIDATION", s.Info.Reason)
+	assert.NotContains(t, s.Info.Metadata, grpcerr.DetailsKey)
+	s = grpcerr.ToStatus(errUserNotFound.Wrap(fmt.Errorf("pq: relation users secret_column")), "")
+	assert.Equal(t, map[string]string{grpcerr.StatusCodeKey: "404"}, s.Info.Metadata)
+
+	assert.Equal(t, &grpcerr.Status{Code: errors.GRPCCanceled, Message: "context canceled"},
+		grpcerr.ToStatus(context.Canceled, ""))
+	assert.Equal(t, errors.GRPCDeadlineExceeded,
+		grpcerr.ToStatus(fmt.Errorf("call: %w", context.DeadlineExceeded), "").Code)
+	assert.Equal(t, &grpcerr.Status{Code: errors.GRPCUnknown, Message: "naruto"},
+		grpcerr.ToStatus(fmt.Errorf("naruto"), ""))
+}
+
+func TestStatusError(t *testing.T) {
+	var s *grpcerr.Status
+	assert.Nil(t, s.Error())
+	assert.Nil(t, (&grpcerr.Status{Code: errors.GRPCOK}).Error())
+
+	e := grpcerr.ToStatus(errUserNotFound.WithParam("id", 42).WithDetails(map[string]interface{}{"team": 7}).
+		WithRetryable(true).WithSeverity(errors.SeverityWarning), "").Error()
+	assert.Equal(t, &errors.Error{
+		StatusCode: http.StatusNotFound,
+		Code:       "USER_NOT_FOUND",
+		Message:    "user 42 not found",
+		Details:    map[string]interface{}{"team": 7.0},
+		Params:     errors.Params{"id": 42.0},
+		GRPCCode:   errors.GRPCNotFound,
+		Retryable:  true,
+		Severity:   errors.SeverityWarning,
+	}, e)
+
+	e = (&grpcerr.Status{Code: errors.GRPCUnavailable, Message: "naruto"}).Error()
+	assert.Equal(t, &errors.Error{StatusCode: http.StatusServiceUnavailable, Message: "naruto",
+		GRPCCode: errors.GRPCUnavailable}, e)
+
+	e = (&grpcerr.Status{Code: errors.GRPCAborted, Info: &grpcerr.ErrorInfo{Reason: "CONFLICT"}}).Error()
+	assert.Equal(t, http.StatusConflict, e.StatusCode)
+	assert.Equal(t, "CONFLICT", e.Code)
+}
+
+func TestStatusJSON(t *testing.T) {
+	s := grpcerr.ToStatus(errUserNotFound.WithParam("id", 42), "users.example.com")
+	b, err := json.Marshal(s)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"code": 5,
+		"message": "user 42 not found",
+		"details": [{
+			"@type": "type.googleapis.com/google.rpc.ErrorInfo",
+			"reason": "USER_NOT_FOUND",
+			"domain": "users.example.com",
+			"metadata": {"http_status": "404", "params": "{\"id\":42}"}
+		}]
+	}`, string(b))
+
+	var p grpcerr.Status
+	assert.NoError(t, json.Unmarshal(b, &p))
+	assert.Equal(t, s, &p)
+
+	b, err = json.Marshal(&grpcerr.Status{Code: errors.GRPCUnknown})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"code": 2}`, string(b))
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"code": 3, "message": "naruto", "details": [
+		{"@type": "type.googleapis.com/google.rpc.BadRequest", "fieldViolations": []},
+		{"@type": "type.googleapis.com/google.rpc.ErrorInfo", "reason": "INVALID"}
+	]}`), &p))
+	assert.Equal(t, grpcerr.Status{Code: errors.GRPCInvalidArgument, Message: "naruto",
+		Info: &grpcerr.ErrorInfo{Reason: "INVALID"}}, p)
+	assert.Error(t, json.Unmarshal([]byte(`{"code": "3"}`), &p))
+	assert.Error(t, json.Unmarshal([]byte(`{"code": 3, "details": [1]}`), &p))
+}
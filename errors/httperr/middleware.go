@@ -0,0 +1,31 @@
+package httperr
+
+import (
+	"net/http"
+
+	"github.com/sinhashubham95/go-utils/errors"
+	"github.com/sinhashubham95/go-utils/log"
+)
+
+// Recoverer is the middleware used to recover the panics in the handler, and write them to the response as
+// the problem details of an internal server error, after logging them along with the stack trace.
+//
+// The panic value is logged but not written to the response, so that the internals are not exposed. The panics
+// with http.ErrAbortHandler are not recovered, since they are used to abort the response.
+func Recoverer(next http.Handler, opts ...Option) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			v := recover()
+			if v == nil {
+				return
+			}
+			if v == http.ErrAbortHandler {
+				panic(v)
+			}
+			log.Error(r.Context()).Stack().Err(errors.FromPanic(v)).Msg("recovered from panic")
+			Write(w, r, &errors.Error{StatusCode: http.StatusInternalServerError, Code: errors.PanicErrorCode,
+				Message: http.StatusText(http.StatusInternalServerError)}, opts...)
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
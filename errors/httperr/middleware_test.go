@@ -0,0 +1,58 @@
+package httperr_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sinhashubham95/go-utils/errors/httperr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecoverer(t *testing.T) {
+	h := httperr.Recoverer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/panic":
+			panic("naruto")
+		case "/abort":
+			panic(http.ErrAbortHandler)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}), httperr.WithTypeURI("https://example.com/problems/"))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/panic", nil))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, httperr.ContentType, w.Header().Get("Content-Type"))
+	var p map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &p))
+	assert.Equal(t, map[string]interface{}{
+		"type":     "https://example.com/problems/PANIC",
+		"title":    "Internal Server Error",
+		"status":   500.0,
+		"detail":   "Internal Server Error",
+		"instance": "/panic",
+		"code":     "PANIC",
+	}, p)
+	assert.NotContains(t, w.Body.String(), "naruto")
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ok", nil))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/abort", nil))
+	})
+
+	server := httptest.NewServer(h)
+	defer server.Close()
+	resp, err := http.Get(server.URL + "/panic")
+	assert.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	e, err := httperr.ParseResponse(resp)
+	assert.NoError(t, err)
+	assert.Equal(t, "PANIC", e.Code)
+	assert.Equal(t, http.StatusInternalServerError, e.StatusCode)
+}
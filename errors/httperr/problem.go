@@ -0,0 +1,277 @@
+// Package httperr is used to exchange the errors over HTTP as the problem details of RFC 9457,
+// with the content type application/problem+json.
+package httperr
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/sinhashubham95/go-utils/errors"
+)
+
+// ContentType is the content type of the problem details.
+const ContentType = "application/problem+json"
+
+// DefaultType is the type of the problems not identified by a URI.
+const DefaultType = "about:blank"
+
+// names of the extension members
+const (
+	CodeMember    = "code"
+	DetailsMember = "details"
+	ParamsMember  = "params"
+	ErrorsMember  = "errors"
+)
+
+var members = map[string]struct{}{"type": {}, "title": {}, "status": {}, "detail": {}, "instance": {}}
+
+// Problem is the problem details of an error.
+type Problem struct {
+	// Type is the URI identifying the type of the problem.
+	Type string
+	// Title is the short summary of the type of the problem.
+	Title string
+	// Status is the HTTP status code of the problem.
+	Status int
+	// Detail is the explanation specific to this occurrence of the problem.
+	Detail string
+	// Instance is the URI identifying this occurrence of the problem.
+	Instance string
+	// Extensions are the additional members of the problem.
+	Extensions map[string]interface{}
+}
+
+// Option is used to configure the problem created from an error.
+type Option func(*options)
+
+type options struct {
+	typeURI  string
+	instance string
+}
+
+// WithTypeURI is used to identify the type of the problems with the URI formed by appending the error code to the
+// given base URI, for example https://example.com/problems/ and NOT_FOUND form
+// https://example.com/problems/NOT_FOUND. Without it, the type of the problems is about:blank.
+func WithTypeURI(base string) Option {
+	return func(o *options) {
+		o.typeURI = base
+	}
+}
+
+// WithInstance is used to set the URI identifying the occurrence of the problem.
+func WithInstance(instance string) Option {
+	return func(o *options) {
+		o.instance = instance
+	}
+}
+
+// FromError is used to create the problem from the error.
+//
+// The problem is created from the outermost *errors.Error or *errors.MultiError in the chain of the error. For an
+// *errors.Error, the status, code, details and params are its own, and the detail is its message localized using the
+// context. The details which are errors, like the causes wrapped using Wrap, are never included, since they can hold
+// the internals, other than an *errors.MultiError, whose errors are listed in the errors member, the same as the
+// errors aggregated by an *errors.MultiError. For any other error, the problem has the status code of the category
+// of the error, like 504 for the timeouts, without the detail, so that the internals are not exposed.
+func FromError(ctx context.Context, err error, opts ...Option) *Problem {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	p := &Problem{Type: DefaultType, Instance: o.instance, Extensions: map[string]interface{}{}}
+	code := ""
+	e, m := errors.Outermost(err)
+	switch {
+	case e != nil:
+		p.Status = e.StatusCode
+		code = e.Code
+		p.Detail = e.LocalizedMessage(ctx)
+		switch d := e.Details.(type) {
+		case nil:
+		case *errors.MultiError:
+			p.Extensions[ErrorsMember] = errorMembers(ctx, d, opts)
+		case error:
+		default:
+			p.Extensions[DetailsMember] = d
+		}
+		if len(e.Params) > 0 {
+			p.Extensions[ParamsMember] = e.Params
+		}
+	case m != nil:
+		p.Status = m.StatusCode()
+		code = m.Code
+		p.Detail = m.Message
+		p.Extensions[ErrorsMember] = errorMembers(ctx, m, opts)
+	default:
+		p.Status = errors.Classify(err).StatusCode()
+	}
+	if p.Status < 400 || p.Status > 599 {
+		p.Status = http.StatusInternalServerError
+	}
+	p.Title = http.StatusText(p.Status)
+	if code != "" {
+		p.Extensions[CodeMember] = code
+		if o.typeURI != "" {
+			p.Type = o.typeURI + code
+		}
+	}
+	return p
+}
+
+// errorMembers returns the problems of the errors aggregated, without the instance.
+func errorMembers(ctx context.Context, m *errors.MultiError, opts []Option) []interface{} {
+	list := make([]interface{}, 0, len(m.Errors))
+	childOpts := append(opts[:len(opts):len(opts)], WithInstance(""))
+	for _, c := range m.Errors {
+		list = append(list, FromError(ctx, c, childOpts...).member())
+	}
+	return list
+}
+
+// Write is used to write the error to the response as the problem details.
+// The message of the error is localized using the context of the request, and the instance is the request URI.
+func Write(w http.ResponseWriter, r *http.Request, err error, opts ...Option) {
+	opts = append([]Option{WithInstance(r.URL.RequestURI())}, opts...)
+	p := FromError(r.Context(), err, opts...)
+	b, e := json.Marshal(p)
+	if e != nil {
+		p = &Problem{Type: DefaultType, Title: http.StatusText(http.StatusInternalServerError),
+			Status: http.StatusInternalServerError, Instance: p.Instance}
+		b, _ = json.Marshal(p)
+	}
+	w.Header().Set("Content-Type", ContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(p.Status)
+	_, _ = w.Write(b)
+}
+
+// Parse is used to read the problem details and convert them to the error.
+func Parse(r io.Reader) (*errors.Error, error) {
+	var p Problem
+	if err := json.NewDecoder(r).Decode(&p); err != nil {
+		return nil, err
+	}
+	return p.Error(), nil
+}
+
+// ParseResponse is used to convert the response to the error, if it has the problem details.
+// The response body is not closed. If the response is not the problem details, the error has just its status code
+// and the status text as the message.
+func ParseResponse(resp *http.Response) (*errors.Error, error) {
+	if t, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); t != ContentType {
+		return &errors.Error{StatusCode: resp.StatusCode, Message: http.StatusText(resp.StatusCode)}, nil
+	}
+	e, err := Parse(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if e.StatusCode == 0 {
+		e.StatusCode = resp.StatusCode
+	}
+	return e, nil
+}
+
+// Error is used to convert the problem to the error.
+//
+// The code, details and params come from the extension members, and the message is the detail, or the title if
+// there is no detail. The errors member is converted to an *errors.MultiError in the details.
+func (p *Problem) Error() *errors.Error {
+	e := &errors.Error{StatusCode: p.Status, Message: p.Detail}
+	if e.Message == "" {
+		e.Message = p.Title
+	}
+	if code, ok := p.Extensions[CodeMember].(string); ok {
+		e.Code = code
+	}
+	if params, ok := p.Extensions[ParamsMember].(map[string]interface{}); ok {
+		e.Params = params
+	}
+	e.Details = p.Extensions[DetailsMember]
+	if list, ok := p.Extensions[ErrorsMember].([]interface{}); ok {
+		m := &errors.MultiError{Code: e.Code, Message: p.Detail}
+		for _, c := range list {
+			if c, ok := c.(map[string]interface{}); ok {
+				m.Errors = append(m.Errors, problemFromMember(c).Error())
+			}
+		}
+		e.Details = m
+	}
+	return e
+}
+
+// MarshalJSON is used to render the problem with the extension members alongside the standard ones.
+// The standard members take precedence over the extension members with the same names.
+func (p *Problem) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.member())
+}
+
+// UnmarshalJSON is used to parse the problem, collecting the members other than the standard ones as extensions.
+func (p *Problem) UnmarshalJSON(b []byte) error {
+	var s struct {
+		Type     string `json:"type"`
+		Title    string `json:"title"`
+		Status   int    `json:"status"`
+		Detail   string `json:"detail"`
+		Instance string `json:"instance"`
+	}
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return err
+	}
+	for k := range members {
+		delete(m, k)
+	}
+	*p = Problem{Type: s.Type, Title: s.Title, Status: s.Status, Detail: s.Detail, Instance: s.Instance,
+		Extensions: m}
+	if p.Type == "" {
+		p.Type = DefaultType
+	}
+	return nil
+}
+
+// member returns the standard members of the problem which are set, along with the extensions.
+func (p *Problem) member() map[string]interface{} {
+	m := make(map[string]interface{}, len(p.Extensions)+5)
+	for k, v := range p.Extensions {
+		m[k] = v
+	}
+	m["type"] = p.Type
+	m["title"] = p.Title
+	m["status"] = p.Status
+	if p.Detail != "" {
+		m["detail"] = p.Detail
+	}
+	if p.Instance != "" {
+		m["instance"] = p.Instance
+	}
+	return m
+}
+
+func problemFromMember(m map[string]interface{}) *Problem {
+	p := &Problem{Extensions: map[string]interface{}{}}
+	for k, v := range m {
+		switch k {
+		case "type":
+			p.Type, _ = v.(string)
+		case "title":
+			p.Title, _ = v.(string)
+		case "status":
+			if s, ok := v.(float64); ok {
+				p.Status = int(s)
+			}
+		case "detail":
+			p.Detail, _ = v.(string)
+		case "instance":
+			p.Instance, _ = v.(string)
+		default:
+			p.Extensions[k] = v
+		}
+	}
+	return p
+}
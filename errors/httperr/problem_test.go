@@ -0,0 +1,213 @@
+package httperr_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sinhashubham95/go-utils/errors"
+	"github.com/sinhashubham95/go-utils/errors/httperr"
+	"github.com/stretchr/testify/assert"
+)
+
+var errUserNotFound = &errors.Error{StatusCode: http.StatusNotFound, Code: "USER_NOT_FOUND",
+	Message: "user {id} not found"}
+
+func TestFromError(t *testing.T) {
+	p := httperr.FromError(context.Background(), errUserNotFound.WithParam("id", 42).WithDetails("naruto"))
+	assert.Equal(t, &httperr.Problem{
+		Type:   httperr.DefaultType,
+		Title:  "Not Found",
+		Status: http.StatusNotFound,
+		Detail: "user 42 not found",
+		Extensions: map[string]interface{}{
+			httperr.CodeMember:    "USER_NOT_FOUND",
+			httperr.DetailsMember: "naruto",
+			httperr.ParamsMember:  errors.Params{"id": 42},
+		},
+	}, p)
+
+	p = httperr.FromError(context.Background(), fmt.Errorf("wrapped: %w", errUserNotFound),
+		httperr.WithTypeURI("https://example.com/problems/"), httperr.WithInstance("/users/42"))
+	assert.Equal(t, "https://example.com/problems/USER_NOT_FOUND", p.Type)
+	assert.Equal(t, "/users/42", p.Instance)
+	assert.Equal(t, http.StatusNotFound, p.Status)
+
+	p = httperr.FromError(context.Background(), fmt.Errorf("secret internals"))
+	assert.Equal(t, &httperr.Problem{Type: httperr.DefaultType, Title: "Internal Server Error",
+		Status: http.StatusInternalServerError, Extensions: map[string]interface{}{}}, p)
+	p = httperr.FromError(context.Background(), fmt.Errorf("call: %w", context.DeadlineExceeded))
+	assert.Equal(t, &httperr.Problem{Type: httperr.DefaultType, Title: "Gateway Timeout",
+		Status: http.StatusGatewayTimeout, Extensions: map[string]interface{}{}}, p)
+	p = httperr.FromError(context.Background(), &errors.Error{Code: "NARUTO"})
+	assert.Equal(t, http.StatusInternalServerError, p.Status)
+
+	bundle := errors.NewBundle().Add("fr", map[string]string{"USER_NOT_FOUND": "utilisateur {id} introuvable"})
+	ctx := errors.WithLocale(errors.WithTranslator(context.Background(), bundle), "fr")
+	p = httperr.FromError(ctx, errUserNotFound.WithParam("id", 42))
+	assert.Equal(t, "utilisateur 42 introuvable", p.Detail)
+}
+
+func TestFromMultiError(t *testing.T) {
+	err := errors.Join(errUserNotFound.WithParam("id", 42), &errors.Error{StatusCode: http.StatusBadRequest,
+		Code: "INVALID", Message: "naruto"}).(*errors.MultiError).WithCode("BATCH_FAILED")
+	p := httperr.FromError(context.Background(), err, httperr.WithInstance("/users"))
+	b, e := json.Marshal(p)
+	assert.NoError(t, e)
+	assert.JSONEq(t, `{
+		"type": "about:blank",
+		"title": "Not Found",
+		"status": 404,
+		"instance": "/users",
+		"code": "BATCH_FAILED",
+		"errors": [
+			{"type": "about:blank", "title": "Not Found", "status": 404, "detail": "user 42 not found",
+				"code": "USER_NOT_FOUND", "params": {"id": 42}},
+			{"type": "about:blank", "title": "Bad Request", "status": 400, "detail": "naruto", "code": "INVALID"}
+		]
+	}`, string(b))
+
+	parsed, e := httperr.Parse(strings.NewReader(string(b)))
+	assert.NoError(t, e)
+	assert.Equal(t, "BATCH_FAILED", parsed.Code)
+	assert.Equal(t, http.StatusNotFound, parsed.StatusCode)
+	m, ok := parsed.Details.(*errors.MultiError)
+	assert.True(t, ok)
+	assert.Equal(t, 2, m.Len())
+	assert.True(t, errors.Is(parsed, &errors.Error{Code: "INVALID"}))
+	assert.Equal(t, "user 42 not found", m.Errors[0].(*errors.Error).RenderMessage())
+}
+
+func TestFromErrorOutermost(t *testing.T) {
+	invalid := &errors.Error{StatusCode: http.StatusUnprocessableEntity, Code: "VALIDATION",
+		Message: "validation failed"}
+	a := &errors.Error{StatusCode: http.StatusBadRequest, Code: "INVALID_NAME", Message: "name is invalid"}
+	b := &errors.Error{StatusCode: http.StatusBadRequest, Code: "INVALID_AGE", Message: "age is invalid"}
+
+	p := httperr.FromError(context.Background(), fmt.Errorf("create: %w", invalid.Wrap(errors.Join(a, b))))
+	body, e := json.Marshal(p)
+	assert.NoError(t, e)
+	assert.JSONEq(t, `{
+		"type": "about:blank",
+		"title": "Unprocessable Entity",
+		"status": 422,
+		"detail": "validation failed",
+		"code": "VALIDATION",
+		"errors": [
+			{"type": "about:blank", "title": "Bad Request", "status": 400, "detail": "name is invalid",
+				"code": "INVALID_NAME"},
+			{"type": "about:blank", "title": "Bad Request", "status": 400, "detail": "age is invalid",
+				"code": "INVALID_AGE"}
+		]
+	}`, string(body))
+
+	// the MultiError wrapping the errors is still used when it is the outermost
+	p = httperr.FromError(context.Background(), errors.Join(invalid.Wrap(a)).(*errors.MultiError).WithCode("BATCH"))
+	assert.Equal(t, "BATCH", p.Extensions[httperr.CodeMember])
+	assert.Equal(t, http.StatusUnprocessableEntity, p.Status)
+}
+
+func TestFromErrorHidesCauses(t *testing.T) {
+	p := httperr.FromError(context.Background(),
+		errUserNotFound.Wrap(fmt.Errorf("pq: relation users secret_column does not exist")))
+	assert.Equal(t, &httperr.Problem{
+		Type:       httperr.DefaultType,
+		Title:      "Not Found",
+		Status:     http.StatusNotFound,
+		Detail:     "user {id} not found",
+		Extensions: map[string]interface{}{httperr.CodeMember: "USER_NOT_FOUND"},
+	}, p)
+	b, err := json.Marshal(p)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(b), "secret_column")
+
+	p = httperr.FromError(context.Background(), errUserNotFound.Wrap(&errors.Error{Code: "INTERNAL_CAUSE"}))
+	assert.NotContains(t, p.Extensions, httperr.DetailsMember)
+}
+
+func TestProblemJSON(t *testing.T) {
+	p := &httperr.Problem{Type: "https://example.com/problems/out-of-credit", Title: "You do not have enough credit.",
+		Status: http.StatusForbidden, Detail: "Your current balance is 30, but that costs 50.",
+		Instance: "/account/12345/msgs/abc", Extensions: map[string]interface{}{"balance": 30.0, "status": "naruto"}}
+	b, err := json.Marshal(p)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "https://example.com/problems/out-of-credit",
+		"title": "You do not have enough credit.",
+		"status": 403,
+		"detail": "Your current balance is 30, but that costs 50.",
+		"instance": "/account/12345/msgs/abc",
+		"balance": 30
+	}`, string(b))
+
+	var q httperr.Problem
+	assert.NoError(t, json.Unmarshal(b, &q))
+	p.Extensions = map[string]interface{}{"balance": 30.0}
+	assert.Equal(t, p, &q)
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"status": 400}`), &q))
+	assert.Equal(t, httperr.Problem{Type: httperr.DefaultType, Status: http.StatusBadRequest,
+		Extensions: map[string]interface{}{}}, q)
+	assert.Error(t, json.Unmarshal([]byte(`{"status": "400"}`), &q))
+	assert.Error(t, json.Unmarshal([]byte(`[]`), &q))
+}
+
+func TestWriteParseResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/problem":
+			httperr.Write(w, r, errUserNotFound.WithParam("id", 42).WithDetails(map[string]interface{}{"id": 42}))
+		case "/invalid":
+			w.Header().Set("Content-Type", httperr.ContentType)
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("naruto"))
+		default:
+			http.Error(w, "naruto", http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/problem?id=42")
+	assert.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, httperr.ContentType, resp.Header.Get("Content-Type"))
+	e, err := httperr.ParseResponse(resp)
+	assert.NoError(t, err)
+	assert.Equal(t, &errors.Error{StatusCode: http.StatusNotFound, Code: "USER_NOT_FOUND",
+		Message: "user 42 not found", Details: map[string]interface{}{"id": 42.0},
+		Params: errors.Params{"id": 42.0}}, e)
+
+	r, err := http.Get(server.URL + "/invalid")
+	assert.NoError(t, err)
+	defer func() { _ = r.Body.Close() }()
+	_, err = httperr.ParseResponse(r)
+	assert.Error(t, err)
+
+	r, err = http.Get(server.URL + "/other")
+	assert.NoError(t, err)
+	defer func() { _ = r.Body.Close() }()
+	e, err = httperr.ParseResponse(r)
+	assert.NoError(t, err)
+	assert.Equal(t, &errors.Error{StatusCode: http.StatusBadGateway, Message: "Bad Gateway"}, e)
+}
+
+func TestWriteInstance(t *testing.T) {
+	w := httptest.NewRecorder()
+	httperr.Write(w, httptest.NewRequest(http.MethodGet, "/users/42?expand=true", nil), errUserNotFound)
+	var p httperr.Problem
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &p))
+	assert.Equal(t, "/users/42?expand=true", p.Instance)
+	assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+
+	w = httptest.NewRecorder()
+	httperr.Write(w, httptest.NewRequest(http.MethodGet, "/users/42", nil),
+		errUserNotFound.WithDetails(func() {}))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &p))
+	assert.Equal(t, "/users/42", p.Instance)
+}
@@ -0,0 +1,118 @@
+package errors
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+type localesKey struct{}
+
+type translatorKey struct{}
+
+// Translator is used to get the message template of the error code in a locale.
+type Translator interface {
+	Translate(locale, code string) (string, bool)
+}
+
+// Bundle is the Translator holding the message templates keyed by the locale and the error code.
+// It is safe for concurrent use.
+type Bundle struct {
+	mu       sync.RWMutex
+	messages map[string]map[string]string
+}
+
+// NewBundle is used to create an empty bundle.
+func NewBundle() *Bundle {
+	return &Bundle{messages: make(map[string]map[string]string)}
+}
+
+// Add is used to add the message templates of the locale, keyed by the error code.
+// The locales are matched ignoring the case, and with _ and - considered the same, so en_US is the same as en-us.
+func (b *Bundle) Add(locale string, messages map[string]string) *Bundle {
+	locale = normaliseLocale(locale)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	m, ok := b.messages[locale]
+	if !ok {
+		m = make(map[string]string, len(messages))
+		b.messages[locale] = m
+	}
+	for code, message := range messages {
+		m[code] = message
+	}
+	return b
+}
+
+// Translate is used to get the message template of the error code in the locale.
+// If the locale has a region, like pt-BR, and there is no template for it, the one of the language pt is used.
+func (b *Bundle) Translate(locale, code string) (string, bool) {
+	locale = normaliseLocale(locale)
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for {
+		if message, ok := b.messages[locale][code]; ok {
+			return message, true
+		}
+		i := strings.LastIndexByte(locale, '-')
+		if i < 0 {
+			return "", false
+		}
+		locale = locale[:i]
+	}
+}
+
+// WithLocale is used to attach the locales preferred by the user to the context, the most preferred first.
+func WithLocale(ctx context.Context, locales ...string) context.Context {
+	return context.WithValue(ctx, localesKey{}, locales)
+}
+
+// Locales is used to get the locales attached to the context, the most preferred first.
+// It is nil if the context is nil.
+func Locales(ctx context.Context) []string {
+	if ctx == nil {
+		return nil
+	}
+	locales, _ := ctx.Value(localesKey{}).([]string)
+	return locales
+}
+
+// WithTranslator is used to attach the translator used to localize the error messages to the context.
+func WithTranslator(ctx context.Context, translator Translator) context.Context {
+	return context.WithValue(ctx, translatorKey{}, translator)
+}
+
+// LocalizedMessage is used to get the message of the error in the most preferred locale of the context
+// for which the translator of the context has a template for the code, rendered with the params.
+// If there is no such locale, or the context is nil, it is the same as RenderMessage.
+func (e *Error) LocalizedMessage(ctx context.Context) string {
+	return Render(e.localizedTemplate(ctx), e.Params)
+}
+
+// Localize is used to create a new error with the message replaced by its template in the most preferred locale
+// of the context, keeping the params. If there is no such locale, or the context is nil, the message is kept.
+func (e *Error) Localize(ctx context.Context) *Error {
+	c := e.clone()
+	c.Message = e.localizedTemplate(ctx)
+	return c
+}
+
+func (e *Error) localizedTemplate(ctx context.Context) string {
+	if ctx == nil {
+		return e.Message
+	}
+	translator, ok := ctx.Value(translatorKey{}).(Translator)
+	if !ok || e.Code == "" {
+		return e.Message
+	}
+	for _, locale := range Locales(ctx) {
+		if message, ok := translator.Translate(locale, e.Code); ok {
+			return message
+		}
+	}
+	return e.Message
+}
+
+func normaliseLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
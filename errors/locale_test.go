@@ -0,0 +1,88 @@
+package errors_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/sinhashubham95/go-utils/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func newBundle() *errors.Bundle {
+	return errors.NewBundle().
+		Add("fr", map[string]string{"USER_NOT_FOUND": "utilisateur {id} introuvable"}).
+		Add("pt_BR", map[string]string{"USER_NOT_FOUND": "usuário {id} não encontrado"}).
+		Add("pt", map[string]string{"USER_NOT_FOUND": "utilizador {id} não encontrado", "CONFLICT": "conflito"})
+}
+
+func TestBundleTranslate(t *testing.T) {
+	b := newBundle()
+	m, ok := b.Translate("fr", "USER_NOT_FOUND")
+	assert.True(t, ok)
+	assert.Equal(t, "utilisateur {id} introuvable", m)
+	m, ok = b.Translate("fr-CA", "USER_NOT_FOUND")
+	assert.True(t, ok)
+	assert.Equal(t, "utilisateur {id} introuvable", m)
+	m, ok = b.Translate("pt-br", "USER_NOT_FOUND")
+	assert.True(t, ok)
+	assert.Equal(t, "usuário {id} não encontrado", m)
+	m, ok = b.Translate("PT_BR", "CONFLICT")
+	assert.True(t, ok)
+	assert.Equal(t, "conflito", m)
+	_, ok = b.Translate("de", "USER_NOT_FOUND")
+	assert.False(t, ok)
+	_, ok = b.Translate("fr", "CONFLICT")
+	assert.False(t, ok)
+}
+
+func TestErrorLocalize(t *testing.T) {
+	e := (&errors.Error{Code: "USER_NOT_FOUND", Message: "user {id} not found"}).WithParam("id", 42)
+	ctx := errors.WithTranslator(context.Background(), newBundle())
+
+	assert.Equal(t, "user 42 not found", e.LocalizedMessage(context.Background()))
+	//nolint:staticcheck // the nil context is allowed
+	assert.Equal(t, "user 42 not found", e.LocalizedMessage(nil))
+	//nolint:staticcheck // the nil context is allowed
+	assert.Equal(t, "user {id} not found", e.Localize(nil).Message)
+	assert.Equal(t, "user 42 not found", e.LocalizedMessage(ctx))
+	assert.Equal(t, "utilisateur 42 introuvable", e.LocalizedMessage(errors.WithLocale(ctx, "fr")))
+	assert.Equal(t, "usuário 42 não encontrado", e.LocalizedMessage(errors.WithLocale(ctx, "de", "pt-BR", "fr")))
+	assert.Equal(t, "utilizador 42 não encontrado", e.LocalizedMessage(errors.WithLocale(ctx, "pt-PT")))
+	assert.Equal(t, "user 42 not found", e.LocalizedMessage(errors.WithLocale(ctx, "de")))
+	assert.Equal(t, "user 42 not found",
+		e.LocalizedMessage(errors.WithLocale(context.Background(), "fr")))
+	assert.Equal(t, []string{"de", "fr"}, errors.Locales(errors.WithLocale(ctx, "de", "fr")))
+	assert.Nil(t, errors.Locales(ctx))
+	//nolint:staticcheck // the nil context is allowed
+	assert.Nil(t, errors.Locales(nil))
+
+	l := e.Localize(errors.WithLocale(ctx, "fr"))
+	assert.Equal(t, "utilisateur {id} introuvable", l.Message)
+	assert.Equal(t, errors.Params{"id": 42}, l.Params)
+	assert.Equal(t, "user {id} not found", e.Message)
+	assert.Equal(t, e.Frames(), l.Frames())
+
+	literal := &errors.Error{Message: "user {id} not found"}
+	assert.Equal(t, "user {id} not found", literal.LocalizedMessage(errors.WithLocale(ctx, "fr")))
+}
+
+func TestBundleConcurrent(t *testing.T) {
+	b := newBundle()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i += 1 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			b.Add("de", map[string]string{"USER_NOT_FOUND": "Benutzer {id} nicht gefunden"})
+		}()
+		go func() {
+			defer wg.Done()
+			_, ok := b.Translate("fr", "USER_NOT_FOUND")
+			assert.True(t, ok)
+		}()
+	}
+	wg.Wait()
+	m, _ := b.Translate("de", "USER_NOT_FOUND")
+	assert.Equal(t, "Benutzer {id} nicht gefunden", m)
+}
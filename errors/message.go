@@ -0,0 +1,74 @@
+package errors
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Params are the values of the named placeholders in the message of the error.
+type Params map[string]interface{}
+
+// WithParam is used to create a new error with the value of the named placeholder in the message set.
+func (e *Error) WithParam(name string, value interface{}) *Error {
+	return e.WithParams(Params{name: value})
+}
+
+// WithParams is used to create a new error with the values of the named placeholders in the message set.
+// The existing values are kept, unless they are overridden by the given ones.
+func (e *Error) WithParams(params Params) *Error {
+	c := e.clone()
+	c.Params = make(Params, len(e.Params)+len(params))
+	for k, v := range e.Params {
+		c.Params[k] = v
+	}
+	for k, v := range params {
+		c.Params[k] = v
+	}
+	return c
+}
+
+// RenderMessage is used to get the message of the error with its named placeholders replaced by the values
+// of the params. The message is rendered only when this is called, so the params remain available separately.
+func (e *Error) RenderMessage() string {
+	return Render(e.Message, e.Params)
+}
+
+// Render is used to replace the named placeholders in the template, like {id} in "user {id} not found",
+// with the values of the params.
+//
+// The placeholders without a value are kept as they are, and the braces can be escaped as {{ and }}.
+func Render(template string, params Params) string {
+	if !strings.ContainsAny(template, "{}") {
+		return template
+	}
+	var b strings.Builder
+	for i := 0; i < len(template); i += 1 {
+		c := template[i]
+		if (c == '{' || c == '}') && i+1 < len(template) && template[i+1] == c {
+			b.WriteByte(c)
+			i += 1
+			continue
+		}
+		if c == '{' {
+			if j := strings.IndexByte(template[i+1:], '}'); j > 0 && isPlaceholder(template[i+1:i+1+j]) {
+				if v, ok := params[template[i+1:i+1+j]]; ok {
+					_, _ = fmt.Fprint(&b, v)
+					i += j + 1
+					continue
+				}
+			}
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+func isPlaceholder(name string) bool {
+	for i := 0; i < len(name); i += 1 {
+		c := name[i]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '.' || c == '-') {
+			return false
+		}
+	}
+	return true
+}
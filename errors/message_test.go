@@ -0,0 +1,61 @@
+package errors_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/sinhashubham95/go-utils/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	params := errors.Params{"id": 42, "name": "naruto", "user.id": "u-1"}
+	assert.Equal(t, "user 42 not found", errors.Render("user {id} not found", params))
+	assert.Equal(t, "naruto 42 u-1", errors.Render("{name} {id} {user.id}", params))
+	assert.Equal(t, "user {missing} {}", errors.Render("user {missing} {}", params))
+	assert.Equal(t, "{id} is {42}", errors.Render("{{id}} is {{{id}}}", params))
+	assert.Equal(t, "{ id } {id", errors.Render("{ id } {id", params))
+	assert.Equal(t, "no placeholders", errors.Render("no placeholders", nil))
+	assert.Equal(t, "{id}", errors.Render("{id}", nil))
+}
+
+func TestErrorParams(t *testing.T) {
+	e := &errors.Error{Message: "user {id} of {team} not found"}
+	p := e.WithParam("id", 42)
+	assert.Equal(t, "user {id} of {team} not found", p.Message)
+	assert.Equal(t, "user 42 of {team} not found", p.RenderMessage())
+	assert.Equal(t, "user 42 of {team} not found", p.Error())
+
+	q := p.WithParams(errors.Params{"team": 7, "id": 43})
+	assert.Equal(t, "user 43 of 7 not found", q.Error())
+	assert.Equal(t, errors.Params{"id": 42}, p.Params)
+	assert.Nil(t, e.Params)
+
+	c := q.WithMessage("user {id} missing")
+	assert.Equal(t, "user 43 missing", c.RenderMessage())
+	assert.Equal(t, "user 43 missing", fmt.Sprintf("%v", c))
+
+	b, err := json.Marshal(&errors.Error{Code: "USER_NOT_FOUND", Message: "user {id} not found",
+		Params: errors.Params{"id": 42}})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"code": "USER_NOT_FOUND", "message": "user 42 not found", "template": "user {id} not found",
+		"params": {"id": 42}}`,
+		string(b))
+
+	// the message without the placeholders replaced has no template
+	b, err = json.Marshal(&errors.Error{Code: "USER_NOT_FOUND", Message: "user {name} not found",
+		Params: errors.Params{"id": 42}})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"code": "USER_NOT_FOUND", "message": "user {name} not found", "params": {"id": 42}}`,
+		string(b))
+}
+
+func TestCatalogNewWithParams(t *testing.T) {
+	c := errors.NewCatalog().MustRegister(errors.Definition{Code: "USER_NOT_FOUND", Message: "user {id} not found"})
+	e := c.NewWithParams("USER_NOT_FOUND", errors.Params{"id": 42})
+	assert.Equal(t, "user {id} not found", e.Message)
+	assert.Equal(t, "user 42 not found", e.RenderMessage())
+	assert.Equal(t, errors.Params{"id": 42}, e.Params)
+	assert.Panics(t, func() { c.NewWithParams("NARUTO", nil) })
+}
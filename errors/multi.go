@@ -0,0 +1,182 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// MultiError is the type which can be used to aggregate many errors into one,
+// for example all the failures collected while validating a request.
+type MultiError struct {
+	Code    string  `json:"code"`
+	Message string  `json:"message"`
+	Errors  []error `json:"details"`
+}
+
+// Join is used to aggregate the given errors into a MultiError.
+// The nil errors are discarded, and if all of them are nil, then it returns nil.
+func Join(errs ...error) error {
+	m := &MultiError{}
+	m.append(errs...)
+	if len(m.Errors) == 0 {
+		return nil
+	}
+	return m
+}
+
+// Append is used to add the given errors to the error.
+// If the error is a MultiError, a copy of it is created with the errors added, otherwise a new MultiError is created
+// holding the error followed by the given errors. The error is never modified, so the package level sentinel errors
+// can be appended to concurrently. The nil errors are discarded, and if all of them are nil, then it returns nil.
+func Append(err error, errs ...error) error {
+	if m, ok := err.(*MultiError); ok {
+		if m == nil {
+			return Join(errs...)
+		}
+		c := m.clone()
+		c.append(errs...)
+		return c
+	}
+	return Join(append([]error{err}, errs...)...)
+}
+
+// WithCode is used to create a new error with the code changed
+func (m *MultiError) WithCode(code string) *MultiError {
+	c := m.clone()
+	c.Code = code
+	return c
+}
+
+// WithMessage is used to create a new error with the message changed
+func (m *MultiError) WithMessage(message string) *MultiError {
+	c := m.clone()
+	c.Message = message
+	return c
+}
+
+// Len returns the number of errors aggregated.
+func (m *MultiError) Len() int {
+	return len(m.Errors)
+}
+
+// StatusCode returns the status code of the most severe of the errors aggregated.
+func (m *MultiError) StatusCode() int {
+	s := 0
+	for _, err := range m.Errors {
+		if c := StatusCode(err); c > s {
+			s = c
+		}
+	}
+	return s
+}
+
+// Error is used to render the errors aggregated as a list.
+func (m *MultiError) Error() string {
+	var b strings.Builder
+	b.WriteString(m.header())
+	b.WriteByte(':')
+	for _, err := range m.Errors {
+		b.WriteString("\n\t* ")
+		b.WriteString(strings.ReplaceAll(err.Error(), "\n", "\n\t"))
+	}
+	return b.String()
+}
+
+// Unwrap is used to get the errors aggregated.
+// It makes Is and As, along with the standard library counterparts, examine each of them.
+func (m *MultiError) Unwrap() []error {
+	return m.Errors
+}
+
+// MarshalJSON is used to render the errors aggregated in the details.
+// The errors other than Error and MultiError are rendered with just their message.
+func (m *MultiError) MarshalJSON() ([]byte, error) {
+	details := make([]interface{}, len(m.Errors))
+	for i, err := range m.Errors {
+		switch err.(type) {
+		case *Error, *MultiError, json.Marshaler:
+			details[i] = err
+		default:
+			details[i] = &Error{Message: err.Error()}
+		}
+	}
+	return json.Marshal(struct {
+		Code    string        `json:"code"`
+		Message string        `json:"message"`
+		Details []interface{} `json:"details"`
+	}{
+		Code:    m.Code,
+		Message: m.header(),
+		Details: details,
+	})
+}
+
+// clone returns a copy of the error, with its own slice of the errors aggregated.
+func (m *MultiError) clone() *MultiError {
+	c := *m
+	c.Errors = append([]error(nil), m.Errors...)
+	return &c
+}
+
+func (m *MultiError) append(errs ...error) {
+	for _, err := range errs {
+		if err != nil {
+			m.Errors = append(m.Errors, err)
+		}
+	}
+}
+
+func (m *MultiError) header() string {
+	if m.Message != "" {
+		return m.Message
+	}
+	if len(m.Errors) == 1 {
+		return "1 error occurred"
+	}
+	return fmt.Sprintf("%d errors occurred", len(m.Errors))
+}
+
+// StatusCode is used to get the status code corresponding to the error.
+//
+// It is the status code of the Error, the most severe status code among the errors aggregated by a MultiError,
+// or the status code of the first Error in the chain of any other error. It is 0 if the error is nil,
+// and the default status code of the category of the error if there is no Error in the chain.
+func StatusCode(err error) int {
+	switch e := err.(type) {
+	case nil:
+		return 0
+	case *Error:
+		return e.StatusCode
+	case *MultiError:
+		return e.StatusCode()
+	}
+	var e *Error
+	if As(err, &e) {
+		return e.StatusCode
+	}
+	return Classify(err).StatusCode()
+}
+
+// Outermost is used to get the first *Error or *MultiError in the chain of the error, following the errors wrapping
+// many errors, like the ones created by the standard library Join, only if there is none in the chain.
+// Only one of them is returned, and both are nil if there is none in the tree of the error.
+func Outermost(err error) (*Error, *MultiError) {
+	for c := err; c != nil; c = Unwrap(c) {
+		switch x := c.(type) {
+		case *Error:
+			return x, nil
+		case *MultiError:
+			return nil, x
+		}
+	}
+	var e *Error
+	if As(err, &e) {
+		return e, nil
+	}
+	var m *MultiError
+	if As(err, &m) {
+		return nil, m
+	}
+	return nil, nil
+}
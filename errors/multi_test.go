@@ -0,0 +1,143 @@
+package errors_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/sinhashubham95/go-utils/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJoin(t *testing.T) {
+	assert.Nil(t, errors.Join())
+	assert.Nil(t, errors.Join(nil, nil))
+
+	err := errors.Join(errors.New("naruto"), nil, fmt.Errorf("boruto"))
+	m, ok := err.(*errors.MultiError)
+	assert.True(t, ok)
+	assert.Equal(t, 2, m.Len())
+	assert.Equal(t, "2 errors occurred:\n\t* naruto\n\t* boruto", err.Error())
+
+	err = errors.Join(&errors.Error{Code: "naruto"})
+	assert.Equal(t, "1 error occurred:\n\t* naruto", err.Error())
+
+	m = errors.Join(errors.New("naruto"), errors.Join(errors.New("sasuke"), errors.New("sakura"))).(*errors.MultiError).
+		WithCode("TEAM_7").WithMessage("team 7 failed")
+	assert.Equal(t, "TEAM_7", m.Code)
+	assert.Equal(t, "team 7 failed:\n\t* naruto\n\t* 2 errors occurred:\n\t\t* sasuke\n\t\t* sakura", m.Error())
+}
+
+func TestAppend(t *testing.T) {
+	var err error
+	assert.Nil(t, errors.Append(err))
+	assert.Nil(t, errors.Append(err, nil))
+	err = errors.Append(err, errors.New("naruto"))
+	assert.Equal(t, 1, err.(*errors.MultiError).Len())
+	err = errors.Append(err, errors.New("boruto"), nil)
+	assert.Equal(t, 2, err.(*errors.MultiError).Len())
+
+	err = errors.Append(errors.New("naruto"), errors.New("boruto"))
+	assert.Equal(t, 2, err.(*errors.MultiError).Len())
+	var m *errors.MultiError
+	err = errors.Append(m, errors.New("naruto"))
+	assert.Equal(t, 1, err.(*errors.MultiError).Len())
+}
+
+func TestMultiErrorImmutable(t *testing.T) {
+	sentinel := errors.Join(errors.New("naruto")).(*errors.MultiError).WithCode("BATCH").WithMessage("batch failed")
+	var wg sync.WaitGroup
+	for n := 0; n < 10; n += 1 {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			code := fmt.Sprintf("BATCH_%d", n)
+			m := sentinel.WithCode(code).WithMessage(code)
+			assert.Equal(t, code, m.Code)
+			assert.Equal(t, code, m.Message)
+			err := errors.Append(sentinel, fmt.Errorf("attempt %d", n)).(*errors.MultiError)
+			assert.Equal(t, 2, err.Len())
+			assert.Equal(t, fmt.Sprintf("attempt %d", n), err.Errors[1].Error())
+		}(n)
+	}
+	wg.Wait()
+	assert.Equal(t, "BATCH", sentinel.Code)
+	assert.Equal(t, "batch failed", sentinel.Message)
+	assert.Equal(t, 1, sentinel.Len())
+}
+
+func TestMultiErrorIsAs(t *testing.T) {
+	notFound := &errors.Error{StatusCode: http.StatusNotFound, Code: "NOT_FOUND"}
+	invalid := &errors.Error{StatusCode: http.StatusBadRequest, Code: "INVALID"}
+	err := errors.Join(fmt.Errorf("naruto"), errors.Join(invalid, errors.Wrap(errors.New("boruto"), notFound)))
+
+	assert.True(t, errors.Is(err, &errors.Error{Code: "INVALID"}))
+	assert.True(t, errors.Is(err, &errors.Error{Code: "NOT_FOUND"}))
+	assert.True(t, errors.Is(err, fmt.Errorf("naruto")))
+	assert.False(t, errors.Is(err, &errors.Error{Code: "CONFLICT"}))
+
+	var e *errors.Error
+	assert.True(t, errors.As(err, &e))
+	assert.Equal(t, "INVALID", e.Code)
+	var m *errors.MultiError
+	assert.True(t, errors.As(err, &m))
+	assert.Equal(t, err, m)
+	var y struct{}
+	assert.False(t, errors.As(err, &y))
+}
+
+func TestMultiErrorStatusCode(t *testing.T) {
+	notFound := &errors.Error{StatusCode: http.StatusNotFound, Code: "NOT_FOUND"}
+	invalid := &errors.Error{StatusCode: http.StatusBadRequest, Code: "INVALID"}
+	assert.Equal(t, http.StatusNotFound, errors.Join(invalid, notFound).(*errors.MultiError).StatusCode())
+	assert.Equal(t, http.StatusInternalServerError,
+		errors.Join(invalid, fmt.Errorf("naruto")).(*errors.MultiError).StatusCode())
+	assert.Equal(t, http.StatusNotFound, errors.Join(errors.Join(invalid), notFound).(*errors.MultiError).StatusCode())
+
+	assert.Equal(t, 0, errors.StatusCode(nil))
+	assert.Equal(t, http.StatusBadRequest, errors.StatusCode(invalid))
+	assert.Equal(t, http.StatusNotFound, errors.StatusCode(errors.Join(invalid, notFound)))
+	assert.Equal(t, http.StatusInternalServerError, errors.StatusCode(fmt.Errorf("naruto")))
+	assert.Equal(t, http.StatusNotFound, errors.StatusCode(fmt.Errorf("naruto: %w", notFound)))
+}
+
+func TestOutermost(t *testing.T) {
+	notFound := &errors.Error{StatusCode: http.StatusNotFound, Code: "NOT_FOUND"}
+	invalid := &errors.Error{StatusCode: http.StatusBadRequest, Code: "INVALID"}
+	multi := errors.Join(notFound, invalid).(*errors.MultiError)
+
+	e, m := errors.Outermost(fmt.Errorf("get: %w", notFound.Wrap(invalid)))
+	assert.Equal(t, "NOT_FOUND", e.Code)
+	assert.Nil(t, m)
+	e, m = errors.Outermost(fmt.Errorf("batch: %w", multi))
+	assert.Nil(t, e)
+	assert.Same(t, multi, m)
+	e, m = errors.Outermost(fmt.Errorf("naruto"))
+	assert.Nil(t, e)
+	assert.Nil(t, m)
+	e, m = errors.Outermost(nil)
+	assert.Nil(t, e)
+	assert.Nil(t, m)
+}
+
+func TestMultiErrorJSON(t *testing.T) {
+	err := errors.Join(&errors.Error{StatusCode: http.StatusBadRequest, Code: "INVALID", Message: "naruto"},
+		fmt.Errorf("boruto"), errors.Join(&errors.Error{Code: "NOT_FOUND", Message: "sasuke"}))
+	b, e := json.Marshal(err)
+	assert.NoError(t, e)
+	assert.JSONEq(t, `{
+		"code": "",
+		"message": "3 errors occurred",
+		"details": [
+			{"code": "INVALID", "message": "naruto"},
+			{"code": "", "message": "boruto"},
+			{"code": "", "message": "1 error occurred", "details": [{"code": "NOT_FOUND", "message": "sasuke"}]}
+		]
+	}`, string(b))
+
+	b, e = json.Marshal(err.(*errors.MultiError).WithCode("VALIDATION").WithMessage("validation failed"))
+	assert.NoError(t, e)
+	assert.Contains(t, string(b), `"code":"VALIDATION","message":"validation failed"`)
+}
@@ -0,0 +1,115 @@
+package errors
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// PanicErrorCode is the code of the errors created from the recovered panics.
+const PanicErrorCode = "PANIC"
+
+// FromPanic is used to create the error from the value recovered from a panic, having the status code 500, the code
+// PANIC, the value in the details and the stack trace starting where the panic occurred. It is nil if the value is.
+//
+// It must be called in the deferred function which recovered the panic, for the stack trace to be available.
+func FromPanic(v interface{}) *Error {
+	if v == nil {
+		return nil
+	}
+	s := callers(0)
+	frames := s.frames()
+	for i, f := range frames {
+		if f.Function == "runtime.gopanic" {
+			// the runtime errors, like the ones on nil maps, are raised by the functions of the runtime itself
+			s.skip = i + 1
+			for s.skip < len(frames) && strings.HasPrefix(frames[s.skip].Function, "runtime.") {
+				s.skip += 1
+			}
+			break
+		}
+	}
+	return &Error{
+		StatusCode: http.StatusInternalServerError,
+		Code:       PanicErrorCode,
+		Message:    fmt.Sprintf("panic: %v", v),
+		Details:    v,
+		Severity:   SeverityCritical,
+		stack:      s,
+	}
+}
+
+// Recover is used to recover from the panic and set the error created from it using FromPanic,
+// by deferring it in the function returning the error, for example
+//
+//	func process() (err error) {
+//		defer errors.Recover(&err)
+//		...
+//	}
+//
+// It must be deferred directly, since recover works only in the deferred functions.
+func Recover(err *error) {
+	if e := FromPanic(recover()); e != nil {
+		*err = e
+	}
+}
+
+// SafeCall is used to call the function, returning the error created from the panic if it panics.
+func SafeCall(fn func() error) (err error) {
+	defer Recover(&err)
+	return fn()
+}
+
+// SafeGo is used to call the function in a new goroutine, recovering from the panic if it panics, so that it does not
+// crash the process. The error returned by the function, or the one created from the panic, is passed to handle.
+func SafeGo(fn func() error, handle func(err error)) {
+	go func() {
+		if err := SafeCall(fn); err != nil && handle != nil {
+			handle(err)
+		}
+	}()
+}
+
+// Group is the collection of the goroutines working on the subtasks of a common task. The first of them to fail
+// or panic cancels the context of the group, and its error is returned by Wait.
+type Group struct {
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	once   sync.Once
+	err    error
+}
+
+// NewGroup is used to create the group, along with its context derived from the given one.
+// The context is canceled when a goroutine of the group fails or panics, or when Wait returns.
+func NewGroup(ctx context.Context) (*Group, context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Group{cancel: cancel}, ctx
+}
+
+// Go is used to call the function in a new goroutine of the group.
+// If it fails or panics, and it is the first to do so, the context of the group is canceled.
+func (g *Group) Go(fn func() error) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		if err := SafeCall(fn); err != nil {
+			g.once.Do(func() {
+				g.err = err
+				if g.cancel != nil {
+					g.cancel()
+				}
+			})
+		}
+	}()
+}
+
+// Wait is used to wait for all the goroutines of the group to complete, returning the first error or panic.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	if g.cancel != nil {
+		g.cancel()
+	}
+	return g.err
+}
@@ -0,0 +1,123 @@
+package errors_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sinhashubham95/go-utils/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func panicking(v interface{}) error {
+	panic(v)
+}
+
+func TestFromPanic(t *testing.T) {
+	assert.Nil(t, errors.FromPanic(nil))
+	func() {
+		defer func() {
+			e := errors.FromPanic(recover())
+			assert.Equal(t, http.StatusInternalServerError, e.StatusCode)
+			assert.Equal(t, errors.PanicErrorCode, e.Code)
+			assert.Equal(t, "panic: naruto", e.Message)
+			assert.Equal(t, "naruto", e.Details)
+			assert.Equal(t, errors.SeverityCritical, e.Severity)
+			assert.True(t, strings.HasSuffix(e.Frames()[0].Function, "errors_test.panicking"))
+		}()
+		_ = panicking("naruto")
+	}()
+}
+
+func TestRecover(t *testing.T) {
+	err := func() (err error) {
+		defer errors.Recover(&err)
+		return panicking(errNotFoundForPanic)
+	}()
+	assert.True(t, errors.Is(err, &errors.Error{Code: errors.PanicErrorCode}))
+	assert.True(t, errors.Is(err, errNotFoundForPanic))
+	assert.True(t, strings.HasSuffix(err.(*errors.Error).Frames()[0].Function, "errors_test.panicking"))
+
+	err = func() (err error) {
+		defer errors.Recover(&err)
+		return fmt.Errorf("naruto")
+	}()
+	assert.EqualError(t, err, "naruto")
+}
+
+var errNotFoundForPanic = &errors.Error{StatusCode: http.StatusNotFound, Code: "NOT_FOUND"}
+
+func TestSafeCall(t *testing.T) {
+	assert.NoError(t, errors.SafeCall(func() error { return nil }))
+	assert.EqualError(t, errors.SafeCall(func() error { return fmt.Errorf("naruto") }), "naruto")
+
+	err := errors.SafeCall(func() error {
+		var m map[string]int
+		m["naruto"] = 1
+		return nil
+	})
+	e, ok := err.(*errors.Error)
+	assert.True(t, ok)
+	assert.Equal(t, errors.PanicErrorCode, e.Code)
+	assert.Contains(t, e.Message, "assignment to entry in nil map")
+	assert.True(t, strings.HasSuffix(e.Frames()[0].Function, "errors_test.TestSafeCall.func3"))
+}
+
+func TestSafeGo(t *testing.T) {
+	errs := make(chan error, 2)
+	errors.SafeGo(func() error { return panicking("naruto") }, func(err error) { errs <- err })
+	errors.SafeGo(func() error { return fmt.Errorf("boruto") }, func(err error) { errs <- err })
+	errors.SafeGo(func() error { return nil }, func(err error) { errs <- err })
+	errors.SafeGo(func() error { return panicking("sasuke") }, nil)
+	messages := []string{(<-errs).Error(), (<-errs).Error()}
+	assert.ElementsMatch(t, []string{errors.PanicErrorCode, "boruto"}, messages)
+	select {
+	case err := <-errs:
+		assert.Fail(t, "unexpected error", err)
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func TestGroup(t *testing.T) {
+	g, ctx := errors.NewGroup(context.Background())
+	var done int32
+	for i := 0; i < 5; i += 1 {
+		g.Go(func() error {
+			atomic.AddInt32(&done, 1)
+			return nil
+		})
+	}
+	assert.NoError(t, g.Wait())
+	assert.Equal(t, int32(5), atomic.LoadInt32(&done))
+	assert.Error(t, ctx.Err())
+
+	g, ctx = errors.NewGroup(context.Background())
+	g.Go(func() error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	g.Go(func() error {
+		return panicking("naruto")
+	})
+	err := g.Wait()
+	assert.True(t, errors.Is(err, &errors.Error{Code: errors.PanicErrorCode}))
+	assert.Equal(t, "naruto", err.(*errors.Error).Details)
+
+	g, ctx = errors.NewGroup(context.Background())
+	g.Go(func() error {
+		return errNotFoundForPanic
+	})
+	g.Go(func() error {
+		<-ctx.Done()
+		return fmt.Errorf("canceled")
+	})
+	assert.Equal(t, errNotFoundForPanic, g.Wait())
+
+	var zero errors.Group
+	zero.Go(func() error { return fmt.Errorf("naruto") })
+	assert.EqualError(t, zero.Wait(), "naruto")
+}
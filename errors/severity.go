@@ -0,0 +1,49 @@
+package errors
+
+// Severity is the severity of the error, telling how urgently it needs attention.
+type Severity string
+
+// Severities of the errors, in the increasing order of urgency
+const (
+	SeverityInfo     Severity = "info"
+	SeverityWarning  Severity = "warning"
+	SeverityError    Severity = "error"
+	SeverityCritical Severity = "critical"
+)
+
+// SeverityOf is used to get the severity of the error. It is empty if the error is nil.
+//
+// It is the severity set on the first *errors.Error in the tree of the error which has one, following both the
+// errors having an Unwrap() error method and the ones having an Unwrap() []error method, in the same order as As.
+// Otherwise, it is the default severity of the category of the error.
+func SeverityOf(err error) Severity {
+	if err == nil {
+		return ""
+	}
+	if s := findSeverity(err); s != "" {
+		return s
+	}
+	return Classify(err).Severity()
+}
+
+func findSeverity(err error) Severity {
+	for err != nil {
+		if e, ok := err.(*Error); ok && e.Severity != "" {
+			return e.Severity
+		}
+		switch x := err.(type) {
+		case unwrap:
+			err = x.Unwrap()
+		case unwrapMulti:
+			for _, c := range x.Unwrap() {
+				if s := findSeverity(c); s != "" {
+					return s
+				}
+			}
+			return ""
+		default:
+			return ""
+		}
+	}
+	return ""
+}
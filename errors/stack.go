@@ -0,0 +1,78 @@
+package errors
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// MaxStackDepth is the maximum number of frames captured in the stack trace of the errors.
+var MaxStackDepth = 32
+
+// Frame is a single frame of the stack trace.
+type Frame struct {
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+// String is used to render the frame in the format used by the runtime for the stack traces.
+func (f Frame) String() string {
+	return fmt.Sprintf("%s\n\t%s:%d", f.Function, f.File, f.Line)
+}
+
+// stack is the program counters of the stack trace, resolved to the frames only when needed,
+// or the frames themselves for the errors decoded using Decode
+type stack struct {
+	pcs     []uintptr
+	skip    int
+	decoded []Frame
+}
+
+// Callers is used to get the frames of the stack trace of the calling goroutine.
+// The argument skip is the number of frames to skip, with 0 identifying the caller of Callers.
+func Callers(skip int) []Frame {
+	return callers(skip).frames()
+}
+
+// callers captures the stack trace, with skip 0 identifying the caller of the function calling callers.
+func callers(skip int) stack {
+	pcs := make([]uintptr, MaxStackDepth)
+	// skip runtime.Callers, callers and the function calling callers
+	n := runtime.Callers(skip+3, pcs)
+	return stack{pcs: pcs[:n:n]}
+}
+
+func (s stack) empty() bool {
+	return len(s.pcs) == 0 && len(s.decoded) == 0
+}
+
+func (s stack) frames() []Frame {
+	if len(s.pcs) == 0 {
+		if s.skip >= len(s.decoded) {
+			return nil
+		}
+		return s.decoded[s.skip:]
+	}
+	r := make([]Frame, 0, len(s.pcs))
+	frames := runtime.CallersFrames(s.pcs)
+	for i := 0; ; i += 1 {
+		f, more := frames.Next()
+		if i >= s.skip && f.Function != "runtime.goexit" {
+			r = append(r, Frame{Function: f.Function, File: f.File, Line: f.Line})
+		}
+		if !more {
+			break
+		}
+	}
+	return r
+}
+
+func (s stack) String() string {
+	frames := s.frames()
+	lines := make([]string, len(frames))
+	for i, f := range frames {
+		lines[i] = f.String()
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,88 @@
+package errors_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/sinhashubham95/go-utils/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func newError() error {
+	return errors.NewSkip("naruto", 1)
+}
+
+func TestFrames(t *testing.T) {
+	e := errors.New("naruto").(*errors.Error)
+	frames := e.Frames()
+	assert.NotEmpty(t, frames)
+	assert.True(t, strings.HasSuffix(frames[0].Function, "errors_test.TestFrames"))
+	assert.True(t, strings.HasSuffix(frames[0].File, "stack_test.go"))
+	assert.Greater(t, frames[0].Line, 0)
+	assert.True(t, strings.HasPrefix(e.GetTrace(), frames[0].String()))
+	for _, f := range frames {
+		assert.NotEqual(t, "runtime.goexit", f.Function)
+	}
+
+	assert.Empty(t, (&errors.Error{Code: "NARUTO"}).Frames())
+	assert.Empty(t, (&errors.Error{Code: "NARUTO"}).GetTrace())
+}
+
+func TestFramesCopied(t *testing.T) {
+	e := errors.New("naruto").(*errors.Error)
+	assert.Equal(t, e.Frames(), e.WithMessage("boruto").Frames())
+	assert.Equal(t, e.Frames(), e.WithStatusCode(400).WithDetails("sasuke").Value().Frames())
+
+	frames := (&errors.Error{Code: "NARUTO"}).WithMessage("boruto").Frames()
+	assert.NotEmpty(t, frames)
+	assert.True(t, strings.HasSuffix(frames[0].Function, "errors_test.TestFramesCopied"))
+}
+
+func TestNewSkip(t *testing.T) {
+	frames := newError().(*errors.Error).Frames()
+	assert.NotEmpty(t, frames)
+	assert.True(t, strings.HasSuffix(frames[0].Function, "errors_test.TestNewSkip"))
+
+	frames = errors.NewSkip("naruto", 0).(*errors.Error).Frames()
+	assert.True(t, strings.HasSuffix(frames[0].Function, "errors_test.TestNewSkip"))
+}
+
+func TestSkipFrames(t *testing.T) {
+	e := errors.New("naruto").(*errors.Error)
+	frames := e.Frames()
+	assert.Equal(t, frames[1:], e.SkipFrames(1).Frames())
+	assert.Equal(t, frames[2:], e.SkipFrames(1).SkipFrames(1).Frames())
+	assert.Empty(t, e.SkipFrames(len(frames)).Frames())
+	assert.Equal(t, frames, e.Frames())
+}
+
+func TestCallers(t *testing.T) {
+	frames := errors.Callers(0)
+	assert.NotEmpty(t, frames)
+	assert.True(t, strings.HasSuffix(frames[0].Function, "errors_test.TestCallers"))
+	assert.Equal(t, frames[1:], errors.Callers(1)[0:len(frames)-1])
+
+	depth := errors.MaxStackDepth
+	defer func() { errors.MaxStackDepth = depth }()
+	errors.MaxStackDepth = 1
+	assert.Len(t, errors.Callers(0), 1)
+	assert.Len(t, errors.New("naruto").(*errors.Error).Frames(), 1)
+}
+
+func TestErrorFormat(t *testing.T) {
+	e := errors.New("naruto").(*errors.Error)
+	assert.Equal(t, "naruto", fmt.Sprintf("%s", e))
+	assert.Equal(t, "naruto", fmt.Sprintf("%v", e))
+	assert.Equal(t, `"naruto"`, fmt.Sprintf("%q", e))
+	assert.Equal(t, "naruto\n"+e.GetTrace(), fmt.Sprintf("%+v", e))
+
+	c := &errors.Error{Code: "NARUTO", Message: "naruto failed"}
+	assert.Equal(t, "NARUTO", fmt.Sprintf("%v", c))
+	assert.Equal(t, "NARUTO: naruto failed", fmt.Sprintf("%+v", c))
+	w := errors.Wrap(c, &errors.Error{Message: "boruto"}).(*errors.Error)
+	assert.True(t, strings.HasSuffix(w.Frames()[0].Function, "errors_test.TestErrorFormat"))
+	assert.Equal(t, "NARUTO: naruto failed\n"+w.GetTrace()+"\ncaused by: boruto", fmt.Sprintf("%+v", w))
+	w = c.Wrap(&errors.Error{Message: "boruto"}).(*errors.Error)
+	assert.True(t, strings.HasSuffix(w.Frames()[0].Function, "errors_test.TestErrorFormat"))
+}
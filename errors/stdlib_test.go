@@ -0,0 +1,51 @@
+//go:build go1.20
+
+package errors_test
+
+import (
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/sinhashubham95/go-utils/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStdlibJoin(t *testing.T) {
+	notFound := &errors.Error{StatusCode: http.StatusNotFound, Code: "NOT_FOUND"}
+	err := stderrors.Join(io.EOF, fmt.Errorf("reading: %w", notFound))
+	assert.True(t, errors.Is(err, io.EOF))
+	assert.True(t, errors.Is(err, &errors.Error{Code: "NOT_FOUND"}))
+	assert.False(t, errors.Is(err, io.ErrUnexpectedEOF))
+	var e *errors.Error
+	assert.True(t, errors.As(err, &e))
+	assert.Equal(t, notFound, e)
+	assert.Equal(t, http.StatusNotFound, errors.StatusCode(err))
+}
+
+func TestStdlibMultipleWrapVerbs(t *testing.T) {
+	invalid := &errors.Error{StatusCode: http.StatusBadRequest, Code: "INVALID"}
+	err := fmt.Errorf("%w and %w", io.EOF, errors.Join(io.ErrClosedPipe, invalid))
+	assert.True(t, errors.Is(err, io.EOF))
+	assert.True(t, errors.Is(err, io.ErrClosedPipe))
+	assert.True(t, errors.Is(err, &errors.Error{Code: "INVALID"}))
+	var m *errors.MultiError
+	assert.True(t, errors.As(err, &m))
+	assert.Equal(t, 2, m.Len())
+}
+
+func TestStdlibOnErrors(t *testing.T) {
+	notFound := &errors.Error{StatusCode: http.StatusNotFound, Code: "NOT_FOUND"}
+	err := errors.Join(io.EOF, errors.Wrap(errors.New("naruto"), notFound))
+	assert.True(t, stderrors.Is(err, io.EOF))
+	assert.True(t, stderrors.Is(err, &errors.Error{Code: "NOT_FOUND"}))
+	assert.False(t, stderrors.Is(err, io.ErrUnexpectedEOF))
+	var e *errors.Error
+	assert.True(t, stderrors.As(err, &e))
+	assert.Equal(t, "naruto", e.Message)
+	var m *errors.MultiError
+	assert.True(t, stderrors.As(fmt.Errorf("wrapped: %w", err), &m))
+	assert.Equal(t, err, m)
+}
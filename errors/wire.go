@@ -0,0 +1,309 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+)
+
+// WireVersion is the version of the wire format in which the errors are encoded by Encode.
+const WireVersion = 1
+
+// Codes of the errors returned while decoding the errors
+const (
+	InvalidWireFormatErrorCode      = "INVALID_WIRE_FORMAT"
+	UnsupportedWireVersionErrorCode = "UNSUPPORTED_WIRE_VERSION"
+)
+
+// Errors returned while decoding the errors
+var (
+	ErrInvalidWireFormat      = &Error{StatusCode: http.StatusInternalServerError, Code: InvalidWireFormatErrorCode}
+	ErrUnsupportedWireVersion = &Error{StatusCode: http.StatusInternalServerError,
+		Code: UnsupportedWireVersionErrorCode}
+)
+
+// types of the errors in the wire format
+const (
+	wireTypeError = "error"
+	wireTypeMulti = "multi"
+	wireTypeOther = "other"
+)
+
+// registry of the types of the details, so that they are decoded to the same types
+var detailsTypes = struct {
+	sync.RWMutex
+	byName map[string]reflect.Type
+	byType map[reflect.Type]string
+}{byName: map[string]reflect.Type{}, byType: map[reflect.Type]string{}}
+
+type wireEnvelope struct {
+	Version int        `json:"version"`
+	Error   *wireError `json:"error"`
+}
+
+type wireError struct {
+	Type        string          `json:"type"`
+	Code        string          `json:"code,omitempty"`
+	Message     string          `json:"message,omitempty"`
+	StatusCode  int             `json:"status,omitempty"`
+	GRPCCode    GRPCCode        `json:"grpcCode,omitempty"`
+	Retryable   bool            `json:"retryable,omitempty"`
+	Severity    Severity        `json:"severity,omitempty"`
+	Category    Category        `json:"category,omitempty"`
+	Params      Params          `json:"params,omitempty"`
+	Fields      []wireField     `json:"fields,omitempty"`
+	DetailsType string          `json:"detailsType,omitempty"`
+	Details     json.RawMessage `json:"details,omitempty"`
+	Cause       *wireError      `json:"cause,omitempty"`
+	Errors      []*wireError    `json:"errors,omitempty"`
+	Trace       []Frame         `json:"trace,omitempty"`
+}
+
+type wireField struct {
+	Key   string      `json:"key"`
+	Value interface{} `json:"value"`
+}
+
+// EncodeOption is used to configure the encoding of the errors.
+type EncodeOption func(*encodeOptions)
+
+type encodeOptions struct {
+	trace bool
+}
+
+// WithTrace is used to include the stack traces of the errors, which are not included by default.
+func WithTrace() EncodeOption {
+	return func(o *encodeOptions) {
+		o.trace = true
+	}
+}
+
+// RegisterDetails is used to register the type of the details with the given name, so that the details of this type
+// are decoded to the same type, rather than to the maps and the slices. The sample is any value of the type, for
+// example RegisterDetails("user", User{}) or RegisterDetails("user", &User{}).
+//
+// The same name must be registered for the type in all the services exchanging the errors.
+// It panics if the name or the type is already registered.
+func RegisterDetails(name string, sample interface{}) {
+	t := reflect.TypeOf(sample)
+	if name == "" || t == nil {
+		panic("details type must have a name and a non nil sample")
+	}
+	detailsTypes.Lock()
+	defer detailsTypes.Unlock()
+	if _, ok := detailsTypes.byName[name]; ok {
+		panic(fmt.Sprintf("details type %q is already registered", name))
+	}
+	if n, ok := detailsTypes.byType[t]; ok {
+		panic(fmt.Sprintf("details type %s is already registered as %q", t, n))
+	}
+	detailsTypes.byName[name] = t
+	detailsTypes.byType[t] = name
+}
+
+// Encode is used to encode the error in the versioned wire format, to be sent to another process,
+// where it is rebuilt using Decode.
+//
+// The whole chain of the error is encoded. The code, message, status codes, retryable flag, severity, category,
+// params, fields and details of each *Error are kept, along with the errors aggregated by each MultiError. The
+// details are decoded to the same type only if their type is registered using RegisterDetails. The other errors
+// are kept with just their message, status code and category, and the errors they wrap.
+// The stack traces are included only WithTrace. It is nil if the error is nil.
+func Encode(err error, opts ...EncodeOption) ([]byte, error) {
+	if err == nil {
+		return nil, nil
+	}
+	o := &encodeOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	w, err := encodeError(err, o)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(wireEnvelope{Version: WireVersion, Error: w})
+}
+
+// Decoded is the error rebuilt by Decode, kept apart from the error returned if the data cannot be decoded.
+type Decoded struct {
+	// Err is the error rebuilt, an *Error or a *MultiError, or nil if no error was encoded
+	Err error
+}
+
+// Decode is used to rebuild the error encoded using Encode, which can be compared with the errors of the same code
+// using Is. The error decoded is nil if the data is empty, and the error returned is an *Error with the code
+// INVALID_WIRE_FORMAT or UNSUPPORTED_WIRE_VERSION if the data cannot be decoded.
+func Decode(data []byte) (Decoded, error) {
+	if len(data) == 0 {
+		return Decoded{}, nil
+	}
+	var env wireEnvelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return Decoded{}, ErrInvalidWireFormat.wrap(err, 0)
+	}
+	if env.Version != WireVersion {
+		return Decoded{}, ErrUnsupportedWireVersion.WithMessage(fmt.Sprintf("unsupported wire version %d",
+			env.Version))
+	}
+	if env.Error == nil {
+		return Decoded{}, nil
+	}
+	e, err := decodeError(env.Error)
+	if err != nil {
+		return Decoded{}, err
+	}
+	return Decoded{Err: e}, nil
+}
+
+func encodeError(err error, o *encodeOptions) (*wireError, error) {
+	var w *wireError
+	switch e := err.(type) {
+	case *Error:
+		w = &wireError{
+			Type:       wireTypeError,
+			Code:       e.Code,
+			Message:    e.Message,
+			StatusCode: e.StatusCode,
+			GRPCCode:   e.GRPCCode,
+			Retryable:  e.Retryable,
+			Severity:   e.Severity,
+			Category:   e.Category,
+			Params:     e.Params,
+		}
+		for _, f := range e.fields {
+			w.Fields = append(w.Fields, wireField{Key: f.key, Value: f.value})
+		}
+		if o.trace {
+			w.Trace = e.Frames()
+		}
+		if d, ok := e.Details.(error); ok {
+			cause, err := encodeError(d, o)
+			if err != nil {
+				return nil, err
+			}
+			w.Cause = cause
+		} else if e.Details != nil {
+			b, err := json.Marshal(e.Details)
+			if err != nil {
+				return nil, err
+			}
+			w.Details = b
+			detailsTypes.RLock()
+			w.DetailsType = detailsTypes.byType[reflect.TypeOf(e.Details)]
+			detailsTypes.RUnlock()
+		}
+		return w, nil
+	case *MultiError:
+		w = &wireError{Type: wireTypeMulti, Code: e.Code, Message: e.Message}
+		return w, encodeErrors(w, e.Errors, o)
+	}
+	w = &wireError{Type: wireTypeOther, Message: err.Error(), StatusCode: StatusCode(err), Category: Classify(err)}
+	switch u := err.(type) {
+	case interface{ Unwrap() error }:
+		if cause := u.Unwrap(); cause != nil {
+			var err error
+			if w.Cause, err = encodeError(cause, o); err != nil {
+				return nil, err
+			}
+		}
+	case interface{ Unwrap() []error }:
+		return w, encodeErrors(w, u.Unwrap(), o)
+	}
+	return w, nil
+}
+
+func encodeErrors(w *wireError, errs []error, o *encodeOptions) error {
+	for _, err := range errs {
+		e, err := encodeError(err, o)
+		if err != nil {
+			return err
+		}
+		w.Errors = append(w.Errors, e)
+	}
+	return nil
+}
+
+func decodeError(w *wireError) (error, error) {
+	switch w.Type {
+	case wireTypeError, wireTypeOther:
+		e := &Error{
+			StatusCode: w.StatusCode,
+			Code:       w.Code,
+			Message:    w.Message,
+			GRPCCode:   w.GRPCCode,
+			Retryable:  w.Retryable,
+			Severity:   w.Severity,
+			Category:   w.Category,
+			Params:     w.Params,
+			stack:      stack{decoded: w.Trace},
+		}
+		for _, f := range w.Fields {
+			e.fields = append(e.fields, field{key: f.Key, value: f.Value})
+		}
+		switch {
+		case w.Cause != nil:
+			cause, err := decodeError(w.Cause)
+			if err != nil {
+				return nil, err
+			}
+			e.Details = cause
+		case len(w.Errors) > 0:
+			// the other errors wrapping many errors
+			m, err := decodeErrors(&MultiError{}, w.Errors)
+			if err != nil {
+				return nil, err
+			}
+			e.Details = m
+		case len(w.Details) > 0:
+			details, err := decodeDetails(w.DetailsType, w.Details)
+			if err != nil {
+				return nil, err
+			}
+			e.Details = details
+		}
+		return e, nil
+	case wireTypeMulti:
+		return decodeErrors(&MultiError{Code: w.Code, Message: w.Message}, w.Errors)
+	default:
+		return nil, ErrInvalidWireFormat.WithMessage(fmt.Sprintf("unknown error type %q", w.Type))
+	}
+}
+
+func decodeErrors(m *MultiError, ws []*wireError) (error, error) {
+	for _, w := range ws {
+		e, err := decodeError(w)
+		if err != nil {
+			return nil, err
+		}
+		m.Errors = append(m.Errors, e)
+	}
+	return m, nil
+}
+
+func decodeDetails(name string, data json.RawMessage) (interface{}, error) {
+	detailsTypes.RLock()
+	t, ok := detailsTypes.byName[name]
+	detailsTypes.RUnlock()
+	if !ok {
+		// the types not registered are decoded to the maps and the slices
+		var v interface{}
+		if err := json.Unmarshal(data, &v); err != nil {
+			return nil, ErrInvalidWireFormat.wrap(err, 1)
+		}
+		return v, nil
+	}
+	if t.Kind() == reflect.Ptr {
+		v := reflect.New(t.Elem())
+		if err := json.Unmarshal(data, v.Interface()); err != nil {
+			return nil, ErrInvalidWireFormat.wrap(err, 1)
+		}
+		return v.Interface(), nil
+	}
+	v := reflect.New(t)
+	if err := json.Unmarshal(data, v.Interface()); err != nil {
+		return nil, ErrInvalidWireFormat.wrap(err, 1)
+	}
+	return v.Elem().Interface(), nil
+}
@@ -0,0 +1,163 @@
+package errors_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/sinhashubham95/go-utils/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type quota struct {
+	Limit     int    `json:"limit"`
+	Remaining int    `json:"remaining"`
+	Resource  string `json:"resource"`
+}
+
+type account struct {
+	ID string `json:"id"`
+}
+
+var errQuotaExceeded = &errors.Error{StatusCode: http.StatusTooManyRequests, Code: "QUOTA_EXCEEDED",
+	Message: "quota of {resource} exceeded"}
+
+func init() {
+	errors.RegisterDetails("test.quota", quota{})
+	errors.RegisterDetails("test.account", &account{})
+}
+
+func TestEncodeDecode(t *testing.T) {
+	original := errQuotaExceeded.WithParam("resource", "orders").
+		WithDetails(quota{Limit: 10, Remaining: 0, Resource: "orders"}).
+		WithRetryable(true).
+		WithSeverity(errors.SeverityWarning).
+		WithCategory(errors.CategoryDependencyFailure).
+		WithGRPCCode(errors.GRPCResourceExhausted).
+		With("user", "naruto")
+	b, err := errors.Encode(fmt.Errorf("calling orders: %w", original))
+	assert.NoError(t, err)
+
+	d, err := errors.Decode(b)
+	assert.NoError(t, err)
+	decoded := d.Err
+	assert.Equal(t, "calling orders: QUOTA_EXCEEDED", decoded.Error())
+	assert.True(t, errors.Is(decoded, errQuotaExceeded))
+	assert.Equal(t, http.StatusTooManyRequests, errors.StatusCode(decoded))
+	assert.Equal(t, map[string]interface{}{"user": "naruto"}, errors.Fields(decoded))
+
+	var e *errors.Error
+	assert.True(t, errors.As(decoded.(*errors.Error).Details.(error), &e))
+	assert.Equal(t, quota{Limit: 10, Remaining: 0, Resource: "orders"}, e.Details)
+	assert.Equal(t, "quota of orders exceeded", e.RenderMessage())
+	assert.True(t, e.Retryable)
+	assert.Equal(t, errors.SeverityWarning, e.Severity)
+	assert.Equal(t, errors.CategoryDependencyFailure, e.Category)
+	assert.Equal(t, errors.GRPCResourceExhausted, e.GRPCCode)
+	assert.Empty(t, e.Frames())
+}
+
+func TestEncodeDecodeChain(t *testing.T) {
+	notFound := &errors.Error{StatusCode: http.StatusNotFound, Code: "ACCOUNT_NOT_FOUND"}
+	err := errors.Join(
+		notFound.WithDetails(&account{ID: "42"}),
+		errors.Wrap(&errors.Error{Code: "LOOKUP_FAILED"}, context.DeadlineExceeded),
+		&errors.Error{Code: "INVALID", Details: map[string]interface{}{"field": "name"}},
+	).(*errors.MultiError).WithCode("BATCH_FAILED")
+
+	b, encodeErr := errors.Encode(err)
+	assert.NoError(t, encodeErr)
+	d, decodeErr := errors.Decode(b)
+	assert.NoError(t, decodeErr)
+	decoded := d.Err
+
+	m, ok := decoded.(*errors.MultiError)
+	assert.True(t, ok)
+	assert.Equal(t, "BATCH_FAILED", m.Code)
+	assert.Len(t, m.Errors, 3)
+	assert.True(t, errors.Is(decoded, notFound))
+	assert.True(t, errors.Is(decoded, &errors.Error{Code: "LOOKUP_FAILED"}))
+	assert.Equal(t, &account{ID: "42"}, m.Errors[0].(*errors.Error).Details)
+	assert.Equal(t, map[string]interface{}{"field": "name"}, m.Errors[2].(*errors.Error).Details)
+
+	cause := m.Errors[1].(*errors.Error).Details.(*errors.Error)
+	assert.Equal(t, context.DeadlineExceeded.Error(), cause.Message)
+	assert.Equal(t, errors.CategoryTimeout, errors.Classify(cause))
+	assert.Equal(t, http.StatusGatewayTimeout, cause.StatusCode)
+}
+
+func TestEncodeTrace(t *testing.T) {
+	err := errors.New("naruto")
+	b, encodeErr := errors.Encode(err)
+	assert.NoError(t, encodeErr)
+	assert.False(t, strings.Contains(string(b), "trace"))
+
+	b, encodeErr = errors.Encode(err, errors.WithTrace())
+	assert.NoError(t, encodeErr)
+	decoded, decodeErr := errors.Decode(b)
+	assert.NoError(t, decodeErr)
+	e := decoded.Err.(*errors.Error)
+	assert.Equal(t, err.(*errors.Error).Frames(), e.Frames())
+	assert.True(t, strings.HasSuffix(e.Frames()[0].Function, "TestEncodeTrace"))
+	assert.Equal(t, e.Frames()[1:], e.SkipFrames(1).Frames())
+	assert.Equal(t, e.Frames(), e.WithMessage("rock lee").Frames())
+}
+
+func TestEncodeDecodeEmpty(t *testing.T) {
+	b, err := errors.Encode(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, b)
+	decoded, err := errors.Decode(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, decoded.Err)
+	decoded, err = errors.Decode([]byte(`{"version": 1}`))
+	assert.NoError(t, err)
+	assert.Nil(t, decoded.Err)
+}
+
+func TestEncodeInvalidDetails(t *testing.T) {
+	_, err := errors.Encode(&errors.Error{Code: "NARUTO", Details: make(chan int)})
+	assert.Error(t, err)
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	decoded, err := errors.Decode([]byte("naruto"))
+	assert.True(t, errors.Is(err, errors.ErrInvalidWireFormat))
+	assert.Nil(t, decoded.Err)
+	_, err = errors.Decode([]byte(`{"version": 2, "error": {"type": "error"}}`))
+	assert.True(t, errors.Is(err, errors.ErrUnsupportedWireVersion))
+	assert.Equal(t, "unsupported wire version 2", err.(*errors.Error).Message)
+	_, err = errors.Decode([]byte(`{"version": 1, "error": {"type": "naruto"}}`))
+	assert.True(t, errors.Is(err, errors.ErrInvalidWireFormat))
+	_, err = errors.Decode([]byte(`{"version": 1, "error": {"type": "error", "detailsType": "test.quota",
+		"details": {"limit": "ten"}}}`))
+	assert.True(t, errors.Is(err, errors.ErrInvalidWireFormat))
+}
+
+func TestRegisterDetails(t *testing.T) {
+	assert.Panics(t, func() { errors.RegisterDetails("test.quota", struct{}{}) })
+	assert.Panics(t, func() { errors.RegisterDetails("test.other", quota{}) })
+	assert.Panics(t, func() { errors.RegisterDetails("", quota{}) })
+	assert.Panics(t, func() { errors.RegisterDetails("test.nil", nil) })
+}
+
+func TestEncodeFormat(t *testing.T) {
+	b, err := errors.Encode(&errors.Error{StatusCode: http.StatusNotFound, Code: "NOT_FOUND",
+		Details: quota{Limit: 1}})
+	assert.NoError(t, err)
+	var m map[string]interface{}
+	assert.NoError(t, json.Unmarshal(b, &m))
+	assert.Equal(t, map[string]interface{}{
+		"version": float64(errors.WireVersion),
+		"error": map[string]interface{}{
+			"type":        "error",
+			"code":        "NOT_FOUND",
+			"status":      float64(http.StatusNotFound),
+			"detailsType": "test.quota",
+			"details":     map[string]interface{}{"limit": float64(1), "remaining": float64(0), "resource": ""},
+		},
+	}, m)
+}
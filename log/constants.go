@@ -2,8 +2,11 @@ package log
 
 // Log Params
 const (
-	CodeLogParam    = "code"
-	MessageLogParam = "message"
-	DetailsLogParam = "details"
-	TraceLogParam   = "trace"
+	CodeLogParam      = "code"
+	MessageLogParam   = "message"
+	DetailsLogParam   = "details"
+	TraceLogParam     = "trace"
+	FieldsLogParam    = "fields"
+	ComponentLogParam = "component"
+	DroppedLogParam   = "dropped"
 )
@@ -0,0 +1,19 @@
+package log
+
+import "time"
+
+// SetSamplerClock is used to set the clock of the burst and the per key samplers in the tests.
+func SetSamplerClock(s Sampler, now func() time.Time) {
+	switch x := s.(type) {
+	case *burstSampler:
+		x.now = now
+	case *perKeySampler:
+		x.now = now
+	}
+}
+
+// SetSamplingClock is used to set the clock reporting the events dropped by the instance in the tests.
+func SetSamplingClock(i *Instance, now func() time.Time) {
+	i.sampling.now = now
+	i.sampling.reported = now().UnixNano()
+}
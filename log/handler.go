@@ -0,0 +1,65 @@
+package log
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/sinhashubham95/go-utils/errors"
+)
+
+// LevelHandler is used to create the handler reading and changing the levels of the instance over HTTP,
+// so that, for example, the debug logs can be enabled without a restart. With a nil instance,
+// the levels of the default instance at the time of each request are used.
+//
+// The GET requests respond with the levels in JSON, for example
+//
+//	{"level": "info", "components": {"db": "debug"}}
+//
+// The PUT and POST requests change them, taking the same JSON in the body, with the level of the instance changed
+// only if present, and the components with the empty levels having their levels removed. They can also take the
+// levels in the query parameter levels, in the format accepted by SetLevels, for example
+//
+//	curl -X PUT 'localhost:8080/log/levels?levels=info,db=debug'
+//
+// They respond with the levels after the change, or with status 400 if any of the levels is invalid.
+func LevelHandler(i *Instance) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		instance := i
+		if instance == nil {
+			instance = Default()
+		}
+		switch r.Method {
+		case http.MethodGet, http.MethodHead:
+		case http.MethodPut, http.MethodPost:
+			if err := setLevels(instance, r); err != nil {
+				writeJSON(w, errors.StatusCode(err), err)
+				return
+			}
+		default:
+			w.Header().Set("Allow", "GET, HEAD, PUT, POST")
+			writeJSON(w, http.StatusMethodNotAllowed, &errors.Error{Code: "METHOD_NOT_ALLOWED",
+				Message: http.StatusText(http.StatusMethodNotAllowed)})
+			return
+		}
+		writeJSON(w, http.StatusOK, instance.Levels())
+	})
+}
+
+func setLevels(i *Instance, r *http.Request) error {
+	if spec := r.URL.Query().Get("levels"); spec != "" {
+		return i.SetLevels(spec)
+	}
+	var levels Levels
+	if err := json.NewDecoder(r.Body).Decode(&levels); err != nil {
+		return ErrInvalidLevel.WithMessage("invalid levels").Wrap(err)
+	}
+	// the levels are validated by rendering them in the format accepted by SetLevels,
+	// with the empty levels of the components rendered as their removal
+	return i.SetLevels(levels.String())
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,60 @@
+package log_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sinhashubham95/go-utils/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func serveLevels(h http.Handler, method, target, body string) (int, map[string]interface{}) {
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(method, target, strings.NewReader(body)))
+	var m map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &m)
+	return w.Code, m
+}
+
+func TestLevelHandler(t *testing.T) {
+	i := log.New(log.WithLevel(log.InfoLevel), log.WithWriter(&syncBuffer{}))
+	h := log.LevelHandler(i)
+
+	status, m := serveLevels(h, http.MethodGet, "/", "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, map[string]interface{}{"level": "info"}, m)
+
+	status, m = serveLevels(h, http.MethodPut, "/", `{"components": {"db": "debug", "http": "warn"}}`)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, map[string]interface{}{"level": "info",
+		"components": map[string]interface{}{"db": "debug", "http": "warn"}}, m)
+
+	status, m = serveLevels(h, http.MethodPost, "/?levels=error,http=", "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, map[string]interface{}{"level": "error",
+		"components": map[string]interface{}{"db": "debug"}}, m)
+	assert.Equal(t, log.Level(log.ErrorLevel), i.GetLevel())
+
+	status, m = serveLevels(h, http.MethodPut, "/", `{"level": "naruto"}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, log.InvalidLevelErrorCode, m["code"])
+	status, _ = serveLevels(h, http.MethodPut, "/", `naruto`)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, log.Level(log.ErrorLevel), i.GetLevel())
+
+	status, _ = serveLevels(h, http.MethodDelete, "/", "")
+	assert.Equal(t, http.StatusMethodNotAllowed, status)
+}
+
+func TestLevelHandlerDefault(t *testing.T) {
+	original := log.Default()
+	defer log.SetDefault(original)
+	log.SetDefault(log.New(log.WithLevel(log.WarnLevel), log.WithWriter(&syncBuffer{})))
+
+	status, m := serveLevels(log.LevelHandler(nil), http.MethodGet, "/", "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "warn", m["level"])
+}
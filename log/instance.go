@@ -0,0 +1,310 @@
+package log
+
+import (
+	"context"
+	"io"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/sinhashubham95/go-utils/errors"
+)
+
+// Instance is a logger with its own level, writer, context params and hooks, so that the subsystems of a process
+// can log separately. The package level functions log using the default instance.
+type Instance struct {
+	backend Backend
+	// zerolog is the backend if it is zerolog, to which the events are written without the records
+	zerolog   *zerologBackend
+	component string
+	params    []string
+	hooks     []Hook
+	levels    *levels
+	level     *int32
+	sampling  *sampling
+	// deferred tells whether it is the component of the default instance at the time of logging, with resolved
+	// caching the component of the last default instance
+	deferred bool
+	resolved atomic.Value
+}
+
+// resolvedComponent is the component of a default instance.
+type resolvedComponent struct {
+	from      *Instance
+	component *Instance
+}
+
+// Hook is called for each event logged by the instance, with the context and the level of the event and its message,
+// when the event is sent. The fields added to the logger are included in the event, and it must not be sent.
+type Hook func(ctx context.Context, level Level, msg string, logger Logger)
+
+// Option is used to configure the instance.
+type Option func(*options)
+
+type options struct {
+	level          Level
+	backend        Backend
+	params         []string
+	hooks          []Hook
+	samplers       map[zerolog.Level]Sampler
+	samplingReport time.Duration
+}
+
+// WithLevel is used to set the minimum level of the events logged, debug by default.
+func WithLevel(level Level) Option {
+	return func(o *options) {
+		o.level = level
+	}
+}
+
+// WithWriter is used to set the writer to which the events are written in JSON using zerolog, os.Stderr by default.
+func WithWriter(w io.Writer) Option {
+	return func(o *options) {
+		if w != nil {
+			o.backend = NewZerologBackend(w)
+		}
+	}
+}
+
+// WithBackend is used to set the backend to which the events are written, like the handler of log/slog using
+// NewSlogBackend or a Recorder, in place of the JSON written using zerolog.
+func WithBackend(b Backend) Option {
+	return func(o *options) {
+		if b != nil {
+			o.backend = b
+		}
+	}
+}
+
+// WithParams is used to add the keys of the values in the context which are added to the events.
+func WithParams(params ...string) Option {
+	return func(o *options) {
+		o.params = append(o.params, params...)
+	}
+}
+
+// WithHooks is used to add the hooks called for each event logged.
+func WithHooks(hooks ...Hook) Option {
+	return func(o *options) {
+		o.hooks = append(o.hooks, hooks...)
+	}
+}
+
+// WithSampler is used to sample the events of the levels using the sampler, so that only the events it decides are
+// logged. The levels are trace, debug, info and warn if none are given, so that the errors are never sampled unless
+// their levels are given. The events of the levels without a sampler are all logged. The events of the panic and
+// the fatal levels which are dropped still panic or exit, only without being written.
+//
+// The number of the events dropped is logged as a warning periodically, every minute by default.
+func WithSampler(sampler Sampler, levels ...Level) Option {
+	if len(levels) == 0 {
+		levels = []Level{TraceLevel, DebugLevel, InfoLevel, WarnLevel}
+	}
+	return func(o *options) {
+		if o.samplers == nil {
+			o.samplers = map[zerolog.Level]Sampler{}
+		}
+		for _, l := range levels {
+			o.samplers[l.zeroLogLevel()] = sampler
+		}
+	}
+}
+
+// WithSamplingReport is used to set the period after which the number of the events dropped by sampling is logged,
+// which is checked when the events are logged. So the events dropped are reported only when a later event is logged
+// after the period, and never if the instance stops logging. It is never logged if the period is not positive.
+func WithSamplingReport(period time.Duration) Option {
+	return func(o *options) {
+		o.samplingReport = period
+	}
+}
+
+// New is used to create the logger instance.
+func New(opts ...Option) *Instance {
+	o := &options{level: DebugLevel, samplingReport: DefaultSamplingReportPeriod}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.backend == nil {
+		o.backend = NewZerologBackend(os.Stderr)
+	}
+	z, _ := o.backend.(*zerologBackend)
+	levels := newLevels(o.level)
+	return &Instance{backend: o.backend, zerolog: z, params: o.params, hooks: o.hooks, levels: levels,
+		level: levels.cell(""), sampling: newSampling(o.samplers, o.samplingReport)}
+}
+
+// Component is used to create the instance for the component of the instance, like the database or the HTTP server,
+// which adds the name of the component to the events, and has the level set for it using SetLevels if any,
+// otherwise the level of the instance. The components are named independently of the instance they are created
+// from, and share the writer, the params, the hooks and the levels with it.
+// It panics if the name is empty.
+func (i *Instance) Component(name string) *Instance {
+	if name == "" {
+		panic("component name must not be empty")
+	}
+	if i.deferred {
+		return &Instance{component: name, deferred: true}
+	}
+	return &Instance{
+		backend:   i.backend,
+		zerolog:   i.zerolog,
+		component: name,
+		params:    i.params,
+		hooks:     i.hooks,
+		levels:    i.levels,
+		level:     i.levels.cell(name),
+		sampling:  i.sampling,
+	}
+}
+
+// get returns the instance logging the events, which is the component of the default instance at the time of
+// logging for the components created using the package level Component.
+func (i *Instance) get() *Instance {
+	if !i.deferred {
+		return i
+	}
+	d := Default()
+	if r, ok := i.resolved.Load().(resolvedComponent); ok && r.from == d {
+		return r.component
+	}
+	c := d.Component(i.component)
+	i.resolved.Store(resolvedComponent{from: d, component: c})
+	return c
+}
+
+// GetLevel is used to get the minimum level of the events logged by the instance.
+func (i *Instance) GetLevel() Level {
+	return levelFromZeroLog(zerolog.Level(atomic.LoadInt32(i.get().level)))
+}
+
+// SetLevel is used to change the minimum level of the events logged by the instance and its components, other than
+// the ones having their own levels set using SetLevels. The invalid levels are considered debug.
+func (i *Instance) SetLevel(level Level) {
+	if level == "" {
+		level = DebugLevel
+	}
+	i.get().levels.set(level, nil)
+}
+
+// SetLevels is used to change the levels of the instance and its components, given in the format
+// "info,db=debug,http=warn", with the entry without a component being the level of the instance, and the entries
+// without a level, like "db=", removing the level of the component, so that it has the level of the instance.
+// It returns an error with the code INVALID_LOG_LEVEL, changing nothing, if any of the levels is invalid.
+func (i *Instance) SetLevels(spec string) error {
+	base, overrides, err := parseLevels(spec)
+	if err != nil {
+		return err
+	}
+	i.get().levels.set(base, overrides)
+	return nil
+}
+
+// Levels is used to get the level of the instance, along with the levels of the components overriding it.
+func (i *Instance) Levels() Levels {
+	return i.get().levels.get()
+}
+
+func (i *Instance) enabled(level zerolog.Level) bool {
+	return level >= zerolog.Level(atomic.LoadInt32(i.get().level))
+}
+
+// Trace is the for trace log
+func (i *Instance) Trace(ctx context.Context) Logger {
+	return i.newL(ctx, zerolog.TraceLevel)
+}
+
+// Debug is the for debug log
+func (i *Instance) Debug(ctx context.Context) Logger {
+	return i.newL(ctx, zerolog.DebugLevel)
+}
+
+// Info is the for info log
+func (i *Instance) Info(ctx context.Context) Logger {
+	return i.newL(ctx, zerolog.InfoLevel)
+}
+
+// Warn is the for warn log
+func (i *Instance) Warn(ctx context.Context) Logger {
+	return i.newL(ctx, zerolog.WarnLevel)
+}
+
+// Error is the for error log
+func (i *Instance) Error(ctx context.Context) Logger {
+	return i.newL(ctx, zerolog.ErrorLevel).Stack()
+}
+
+// Panic is the for panic log
+func (i *Instance) Panic(ctx context.Context) Logger {
+	return i.newL(ctx, zerolog.PanicLevel).Stack()
+}
+
+// Fatal is the for fatal log
+func (i *Instance) Fatal(ctx context.Context) Logger {
+	return i.newL(ctx, zerolog.FatalLevel).Stack()
+}
+
+// ErrorWarn checks for the error object, and logs it at the level corresponding to its severity, the same as the
+// package level ErrorWarn.
+func (i *Instance) ErrorWarn(ctx context.Context, err error) Logger {
+	switch errors.SeverityOf(err) {
+	case errors.SeverityInfo:
+		return i.Info(ctx).Err(err)
+	case errors.SeverityWarning:
+		return i.Warn(ctx).Err(err)
+	default:
+		return i.Error(ctx).Err(err)
+	}
+}
+
+func (i *Instance) newL(ctx context.Context, level zerolog.Level) *l {
+	i = i.get()
+	if !i.enabled(level) {
+		// the methods of the nil events and records do nothing
+		return newL(i, ctx, level, nil, nil)
+	}
+	return i.event(ctx, level)
+}
+
+// event creates the Logger of the event of the level, with the component and the params in the context.
+func (i *Instance) event(ctx context.Context, level zerolog.Level) *l {
+	if i.zerolog != nil {
+		e := i.zerolog.event(level).Timestamp()
+		if i.component != "" {
+			e.Str(ComponentLogParam, i.component)
+		}
+		if ctx != nil {
+			for _, k := range i.params {
+				if v := ctx.Value(k); v != nil {
+					e.Interface(k, v)
+				}
+			}
+			e.Ctx(ctx)
+		}
+		return newL(i, ctx, level, e, nil)
+	}
+	r := &Record{Level: levelFromZeroLog(level), Context: ctx}
+	if i.component != "" {
+		r.add(ComponentLogParam, i.component)
+	}
+	if ctx != nil {
+		for _, k := range i.params {
+			if v := ctx.Value(k); v != nil {
+				r.add(k, v)
+			}
+		}
+	}
+	return newL(i, ctx, level, nil, r)
+}
+
+// reportDropped logs the number of the events dropped by sampling, if it is time, without sampling it.
+func (i *Instance) reportDropped() {
+	dropped := i.sampling.report()
+	if len(dropped) == 0 {
+		return
+	}
+	x := i.event(context.Background(), zerolog.WarnLevel)
+	x.sample = false
+	x.Interface(DroppedLogParam, dropped).Msg("log events dropped by sampling")
+}
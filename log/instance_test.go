@@ -0,0 +1,118 @@
+package log_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/sinhashubham95/go-utils/log"
+	"github.com/stretchr/testify/assert"
+)
+
+type syncBuffer struct {
+	mu sync.Mutex
+	b  bytes.Buffer
+}
+
+func (s *syncBuffer) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.b.Write(p)
+}
+
+func (s *syncBuffer) lines(t *testing.T) []map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var r []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(s.b.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var m map[string]interface{}
+		assert.NoError(t, json.Unmarshal([]byte(line), &m))
+		r = append(r, m)
+	}
+	return r
+}
+
+func TestNew(t *testing.T) {
+	var db, http syncBuffer
+	dbLogger := log.New(log.WithLevel(log.WarnLevel), log.WithWriter(&db), log.WithParams("request"))
+	httpLogger := log.New(log.WithWriter(&http))
+	ctx := context.WithValue(context.Background(), "request", "naruto")
+
+	dbLogger.Info(ctx).Msg("ignored")
+	dbLogger.Warn(ctx).Msg("slow query")
+	httpLogger.Info(ctx).Str("path", "/users").Msg("request")
+
+	lines := db.lines(t)
+	assert.Len(t, lines, 1)
+	assert.Equal(t, "slow query", lines[0][zerolog.MessageFieldName])
+	assert.Equal(t, "naruto", lines[0]["request"])
+	assert.Contains(t, lines[0][zerolog.CallerFieldName], "instance_test.go")
+
+	lines = http.lines(t)
+	assert.Len(t, lines, 1)
+	assert.Equal(t, "/users", lines[0]["path"])
+	_, ok := lines[0]["request"]
+	assert.False(t, ok)
+}
+
+func TestNewHooks(t *testing.T) {
+	var b syncBuffer
+	var levels []log.Level
+	logger := log.New(log.WithWriter(&b), log.WithHooks(func(ctx context.Context, level log.Level, msg string,
+		l log.Logger) {
+		levels = append(levels, level)
+		l.Str("hooked", msg).Str("tenant", ctx.Value("tenant").(string))
+	}))
+	ctx := context.WithValue(context.Background(), "tenant", "konoha")
+	logger.Info(ctx).Msg("naruto")
+	logger.ErrorWarn(ctx, context.Canceled).Msg("canceled")
+
+	assert.Equal(t, []log.Level{log.InfoLevel, log.InfoLevel}, levels)
+	lines := b.lines(t)
+	assert.Equal(t, "naruto", lines[0]["hooked"])
+	assert.Equal(t, "konoha", lines[0]["tenant"])
+	assert.Equal(t, "canceled", lines[1]["hooked"])
+}
+
+func TestSetDefault(t *testing.T) {
+	original := log.Default()
+	defer log.SetDefault(original)
+
+	var b syncBuffer
+	log.SetDefault(log.New(log.WithWriter(&b)))
+	log.Info(context.Background()).Msg("naruto")
+	log.SetDefault(log.New(log.WithWriter(&syncBuffer{})))
+	log.Info(context.Background()).Msg("rock lee")
+
+	lines := b.lines(t)
+	assert.Len(t, lines, 1)
+	assert.Equal(t, "naruto", lines[0][zerolog.MessageFieldName])
+	assert.Panics(t, func() { log.SetDefault(nil) })
+}
+
+func TestInstanceConcurrent(t *testing.T) {
+	original := log.Default()
+	defer log.SetDefault(original)
+
+	var b syncBuffer
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i += 1 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			log.SetDefault(log.New(log.WithWriter(&b)))
+		}()
+		go func() {
+			defer wg.Done()
+			log.Info(context.Background()).Msg("naruto")
+		}()
+	}
+	wg.Wait()
+}
@@ -35,3 +35,24 @@ func (l Level) zeroLogLevel() zerolog.Level {
 		return zerolog.DebugLevel
 	}
 }
+
+func levelFromZeroLog(l zerolog.Level) Level {
+	switch l {
+	case zerolog.TraceLevel:
+		return TraceLevel
+	case zerolog.DebugLevel:
+		return DebugLevel
+	case zerolog.InfoLevel:
+		return InfoLevel
+	case zerolog.WarnLevel:
+		return WarnLevel
+	case zerolog.ErrorLevel:
+		return ErrorLevel
+	case zerolog.FatalLevel:
+		return FatalLevel
+	case zerolog.PanicLevel:
+		return PanicLevel
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,152 @@
+package log
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/sinhashubham95/go-utils/errors"
+)
+
+// InvalidLevelErrorCode is the code of the error returned for the invalid levels.
+const InvalidLevelErrorCode = "INVALID_LOG_LEVEL"
+
+// ErrInvalidLevel is the error returned for the invalid levels.
+var ErrInvalidLevel = &errors.Error{StatusCode: http.StatusBadRequest, Code: InvalidLevelErrorCode}
+
+// Levels is the level of an instance, along with the levels of its components overriding it.
+type Levels struct {
+	Level      Level            `json:"level"`
+	Components map[string]Level `json:"components,omitempty"`
+}
+
+// levels is the level of an instance and the overrides of its components, shared by the instances derived from it.
+// The effective level of each of the instances is kept in a cell, so that it is read without locking when logging.
+type levels struct {
+	mu        sync.RWMutex
+	base      Level
+	overrides map[string]Level
+	cells     map[string]*int32
+}
+
+// ParseLevel is used to parse the level, ignoring the case and the surrounding spaces.
+// It returns an error with the code INVALID_LOG_LEVEL if it is not one of the levels.
+func ParseLevel(s string) (Level, error) {
+	switch l := Level(strings.ToLower(strings.TrimSpace(s))); l {
+	case TraceLevel, DebugLevel, InfoLevel, WarnLevel, ErrorLevel, FatalLevel, PanicLevel:
+		return l, nil
+	}
+	return "", ErrInvalidLevel.WithMessage(fmt.Sprintf("invalid log level %q", s))
+}
+
+func newLevels(base Level) *levels {
+	return &levels{base: levelFromZeroLog(base.zeroLogLevel()), overrides: map[string]Level{},
+		cells: map[string]*int32{}}
+}
+
+// cell returns the cell of the effective level of the component, with the empty component being the instance itself.
+func (s *levels) cell(component string) *int32 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.cells[component]
+	if !ok {
+		c = new(int32)
+		*c = int32(s.effective(component).zeroLogLevel())
+		s.cells[component] = c
+	}
+	return c
+}
+
+func (s *levels) get() Levels {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	r := Levels{Level: s.base}
+	if len(s.overrides) > 0 {
+		r.Components = make(map[string]Level, len(s.overrides))
+		for k, v := range s.overrides {
+			r.Components[k] = v
+		}
+	}
+	return r
+}
+
+// set changes the level, if not empty, and the overrides of the components, removing the empty ones.
+func (s *levels) set(base Level, overrides map[string]Level) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if base != "" {
+		s.base = levelFromZeroLog(base.zeroLogLevel())
+	}
+	for k, v := range overrides {
+		if v == "" {
+			delete(s.overrides, k)
+			continue
+		}
+		s.overrides[k] = levelFromZeroLog(v.zeroLogLevel())
+	}
+	for k, c := range s.cells {
+		atomic.StoreInt32(c, int32(s.effective(k).zeroLogLevel()))
+	}
+}
+
+func (s *levels) effective(component string) Level {
+	if l, ok := s.overrides[component]; ok && component != "" {
+		return l
+	}
+	return s.base
+}
+
+// parse parses the levels in the format "info,db=debug,http=warn", with the level of the instance without a
+// component, and the empty levels removing the overrides of the components.
+func parseLevels(spec string) (Level, map[string]Level, error) {
+	var base Level
+	overrides := map[string]Level{}
+	for _, entry := range strings.Split(spec, ",") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		component, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			l, err := ParseLevel(entry)
+			if err != nil {
+				return "", nil, err
+			}
+			base = l
+			continue
+		}
+		component = strings.TrimSpace(component)
+		if component == "" {
+			return "", nil, ErrInvalidLevel.WithMessage(fmt.Sprintf("missing component in %q", entry))
+		}
+		if strings.TrimSpace(value) == "" {
+			overrides[component] = ""
+			continue
+		}
+		l, err := ParseLevel(value)
+		if err != nil {
+			return "", nil, err
+		}
+		overrides[component] = l
+	}
+	return base, overrides, nil
+}
+
+// String is used to render the levels in the format accepted by SetLevels, with the components sorted.
+func (l Levels) String() string {
+	entries := make([]string, 0, len(l.Components)+1)
+	if l.Level != "" {
+		entries = append(entries, string(l.Level))
+	}
+	components := make([]string, 0, len(l.Components))
+	for k := range l.Components {
+		components = append(components, k)
+	}
+	sort.Strings(components)
+	for _, k := range components {
+		entries = append(entries, k+"="+string(l.Components[k]))
+	}
+	return strings.Join(entries, ",")
+}
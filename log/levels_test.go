@@ -0,0 +1,123 @@
+package log_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/sinhashubham95/go-utils/errors"
+	"github.com/sinhashubham95/go-utils/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLevel(t *testing.T) {
+	l, err := log.ParseLevel(" WARN ")
+	assert.NoError(t, err)
+	assert.Equal(t, log.Level(log.WarnLevel), l)
+	_, err = log.ParseLevel("naruto")
+	assert.True(t, errors.Is(err, log.ErrInvalidLevel))
+	assert.Equal(t, `invalid log level "naruto"`, err.(*errors.Error).Message)
+}
+
+func TestSetLevel(t *testing.T) {
+	var b syncBuffer
+	i := log.New(log.WithLevel(log.WarnLevel), log.WithWriter(&b))
+	assert.Equal(t, log.Level(log.WarnLevel), i.GetLevel())
+	i.Info(context.Background()).Msg("ignored")
+
+	i.SetLevel(log.TraceLevel)
+	assert.Equal(t, log.Level(log.TraceLevel), i.GetLevel())
+	i.Trace(context.Background()).Msg("traced")
+	i.SetLevel("naruto")
+	assert.Equal(t, log.Level(log.DebugLevel), i.GetLevel())
+
+	lines := b.lines(t)
+	assert.Len(t, lines, 1)
+	assert.Equal(t, "traced", lines[0][zerolog.MessageFieldName])
+}
+
+func TestSetLevels(t *testing.T) {
+	var b syncBuffer
+	i := log.New(log.WithLevel(log.InfoLevel), log.WithWriter(&b))
+	db, http := i.Component("db"), i.Component("http")
+	assert.Panics(t, func() { i.Component("") })
+
+	assert.NoError(t, i.SetLevels("warn, db=debug ,http=error"))
+	assert.Equal(t, log.Levels{Level: log.WarnLevel,
+		Components: map[string]log.Level{"db": log.DebugLevel, "http": log.ErrorLevel}}, i.Levels())
+	assert.Equal(t, "warn,db=debug,http=error", i.Levels().String())
+	assert.Equal(t, log.Level(log.DebugLevel), db.GetLevel())
+	assert.Equal(t, log.Level(log.ErrorLevel), http.GetLevel())
+	assert.Equal(t, log.Level(log.WarnLevel), i.Component("cache").GetLevel())
+
+	db.Debug(context.Background()).Msg("query")
+	http.Warn(context.Background()).Msg("ignored")
+	i.Info(context.Background()).Msg("ignored")
+
+	assert.NoError(t, i.SetLevels("http="))
+	assert.Equal(t, log.Level(log.WarnLevel), http.GetLevel())
+	http.Warn(context.Background()).Msg("request")
+	i.SetLevel(log.ErrorLevel)
+	assert.Equal(t, log.Level(log.ErrorLevel), http.GetLevel())
+	assert.Equal(t, log.Level(log.DebugLevel), db.GetLevel())
+
+	err := i.SetLevels("info,db=naruto")
+	assert.True(t, errors.Is(err, log.ErrInvalidLevel))
+	assert.True(t, errors.Is(i.SetLevels("=debug"), log.ErrInvalidLevel))
+	assert.Equal(t, log.Level(log.ErrorLevel), i.GetLevel())
+
+	lines := b.lines(t)
+	assert.Len(t, lines, 2)
+	assert.Equal(t, "query", lines[0][zerolog.MessageFieldName])
+	assert.Equal(t, "db", lines[0][log.ComponentLogParam])
+	assert.Equal(t, "request", lines[1][zerolog.MessageFieldName])
+	assert.Equal(t, "http", lines[1][log.ComponentLogParam])
+}
+
+func TestPackageLevels(t *testing.T) {
+	original := log.Default()
+	defer log.SetDefault(original)
+
+	var b syncBuffer
+	log.SetDefault(log.New(log.WithWriter(&b)))
+	log.SetLevel(log.WarnLevel)
+	assert.Equal(t, log.Level(log.WarnLevel), log.GetLevel())
+	assert.NoError(t, log.SetLevels("db=info"))
+	log.Component("db").Info(context.Background()).Msg("query")
+	log.Info(context.Background()).Msg("ignored")
+
+	lines := b.lines(t)
+	assert.Len(t, lines, 1)
+	assert.Equal(t, "query", lines[0][zerolog.MessageFieldName])
+}
+
+func TestPackageComponent(t *testing.T) {
+	original := log.Default()
+	defer log.SetDefault(original)
+
+	// the component is created before the default instance is set, like in the package level variables
+	db := log.Component("db")
+	assert.Panics(t, func() { log.Component("") })
+	var b syncBuffer
+	log.SetDefault(log.New(log.WithLevel(log.InfoLevel), log.WithWriter(&b)))
+	db.Debug(context.Background()).Msg("ignored")
+	assert.NoError(t, log.SetLevels("db=debug"))
+	assert.Equal(t, log.Level(log.DebugLevel), db.GetLevel())
+	db.Debug(context.Background()).Msg("query")
+	db.Component("cache").Info(context.Background()).Msg("hit")
+
+	lines := b.lines(t)
+	assert.Len(t, lines, 2)
+	assert.Equal(t, "query", lines[0][zerolog.MessageFieldName])
+	assert.Equal(t, "db", lines[0][log.ComponentLogParam])
+	assert.Equal(t, "cache", lines[1][log.ComponentLogParam])
+
+	var c syncBuffer
+	log.SetDefault(log.New(log.WithLevel(log.WarnLevel), log.WithWriter(&c)))
+	db.Info(context.Background()).Msg("ignored")
+	db.Warn(context.Background()).Msg("slow query")
+	lines = c.lines(t)
+	assert.Len(t, lines, 1)
+	assert.Equal(t, "slow query", lines[0][zerolog.MessageFieldName])
+	assert.Len(t, b.lines(t), 2)
+}
@@ -2,28 +2,40 @@ package log
 
 import (
 	"context"
+	"encoding/json"
 	"io"
-	"runtime/debug"
 	"sync"
 
-	"github.com/rs/zerolog"
-	"github.com/rs/zerolog/log"
 	"github.com/sinhashubham95/go-utils/errors"
 )
 
 var o = &sync.Once{}
 var mu = &sync.RWMutex{}
-var p []string
+var defaultInstance = New()
 
-// InitLogger is used to initialize logger
+// Default is used to get the default instance, used by the package level functions.
+func Default() *Instance {
+	mu.RLock()
+	defer mu.RUnlock()
+	return defaultInstance
+}
+
+// SetDefault is used to swap the default instance, used by the package level functions. It panics if it is nil.
+func SetDefault(i *Instance) {
+	if i == nil {
+		panic("default logger instance must not be nil")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	defaultInstance = i
+}
+
+// InitLogger is used to initialize logger.
+// It sets the default instance only the first time it is called. The level is the one of the default instance
+// and its components, and does not affect the other instances.
 func InitLogger(level Level, params []string) {
 	o.Do(func() {
-		zerolog.ErrorStackMarshaler = getErrorStackMarshaller()
-		zerolog.SetGlobalLevel(level.zeroLogLevel())
-		mu.Lock()
-		defer mu.Unlock()
-		log.Logger = log.With().Caller().Logger()
-		p = params
+		SetDefault(New(WithLevel(level), WithParams(params...)))
 	})
 }
 
@@ -34,87 +46,133 @@ func InitLoggerWithWriter(level Level, w io.Writer, params []string) {
 		return
 	}
 	o.Do(func() {
-		zerolog.ErrorStackMarshaler = getErrorStackMarshaller()
-		zerolog.SetGlobalLevel(level.zeroLogLevel())
-		log.Logger = zerolog.New(w).With().Caller().Timestamp().Logger()
-		mu.Lock()
-		defer mu.Unlock()
-		p = params
+		SetDefault(New(WithLevel(level), WithWriter(w), WithParams(params...)))
 	})
 }
 
 // Trace is the for trace log
 func Trace(ctx context.Context) Logger {
-	return newL(withParams(ctx, log.Trace()))
+	return Default().Trace(ctx)
 }
 
 // Debug is the for debug log
 func Debug(ctx context.Context) Logger {
-	return newL(withParams(ctx, log.Debug()))
+	return Default().Debug(ctx)
 }
 
 // Info is the for info log
 func Info(ctx context.Context) Logger {
-	return newL(withParams(ctx, log.Info()))
+	return Default().Info(ctx)
 }
 
 // Warn is the for warn log
 func Warn(ctx context.Context) Logger {
-	return newL(withParams(ctx, log.Warn()))
+	return Default().Warn(ctx)
 }
 
 // Error is the for error log
 func Error(ctx context.Context) Logger {
-	return newL(withParams(ctx, log.Error().Stack()))
+	return Default().Error(ctx)
 }
 
 // Panic is the for panic log
 func Panic(ctx context.Context) Logger {
-	return newL(withParams(ctx, log.Panic().Stack()))
+	return Default().Panic(ctx)
 }
 
 // Fatal is the for fatal log
 func Fatal(ctx context.Context) Logger {
-	return newL(withParams(ctx, log.Fatal().Stack()))
+	return Default().Fatal(ctx)
+}
+
+// Component is used to create the instance for the component of the default instance, the same as
+// Instance.Component. The default instance is the one at the time of logging, so that the components can be created
+// in the package level variables, and still use the writer, the params and the levels of the default instance set
+// later using SetDefault, SetLevels or LevelHandler.
+// It panics if the name is empty.
+func Component(name string) *Instance {
+	if name == "" {
+		panic("component name must not be empty")
+	}
+	return &Instance{component: name, deferred: true}
+}
+
+// GetLevel is used to get the minimum level of the events logged by the default instance.
+func GetLevel() Level {
+	return Default().GetLevel()
+}
+
+// SetLevel is used to change the minimum level of the events logged by the default instance and its components,
+// the same as Instance.SetLevel.
+func SetLevel(level Level) {
+	Default().SetLevel(level)
 }
 
-// ErrorWarn checks for the error object.
-// In case it is corresponding to a 4XX status code, it logs it as warning.
-// Otherwise, it logs it as an error.
+// SetLevels is used to change the levels of the default instance and its components, given in the format
+// "info,db=debug,http=warn", the same as Instance.SetLevels.
+func SetLevels(spec string) error {
+	return Default().SetLevels(spec)
+}
+
+// ErrorWarn checks for the error object, and logs it at the level corresponding to its severity.
+// The warnings, like the ones corresponding to a 4XX status code, are logged as warning, the informational ones,
+// like the canceled operations, as info, and the rest as an error.
+// For the errors aggregated using errors.Join, the most severe status code among them is considered.
 func ErrorWarn(ctx context.Context, err error) Logger {
-	if e, ok := err.(*errors.Error); ok && e.StatusCode >= 400 && e.StatusCode < 500 {
-		return Warn(ctx).Err(err)
+	return Default().ErrorWarn(ctx, err)
+}
+
+// getErrorStackMarshalled renders the outermost *errors.Error or *errors.MultiError in the chain of the error along
+// with its stack trace, or the stack trace of the caller for the other errors.
+func getErrorStackMarshalled(err error) interface{} {
+	e, m := errors.Outermost(err)
+	switch {
+	case e != nil:
+		return map[string]interface{}{
+			CodeLogParam:    e.Code,
+			MessageLogParam: e.RenderMessage(),
+			DetailsLogParam: getDetailsMarshalled(e.Details),
+			TraceLogParam:   e.Frames(),
+		}
+	case m != nil:
+		return getMultiErrorMarshalled(m)
 	}
-	return Error(ctx).Err(err)
-}
-
-func getErrorStackMarshaller() func(err error) interface{} {
-	return func(err error) interface{} {
-		if err != nil {
-			if e, ok := err.(*errors.Error); ok {
-				return map[string]interface{}{
-					CodeLogParam:    e.Code,
-					MessageLogParam: e.Message,
-					DetailsLogParam: e.Details,
-					TraceLogParam:   e.GetTrace(),
-				}
+	return errors.Callers(0)
+}
+
+func getMultiErrorMarshalled(e *errors.MultiError) interface{} {
+	details := make([]interface{}, len(e.Errors))
+	for i, err := range e.Errors {
+		switch c := err.(type) {
+		case *errors.Error:
+			details[i] = map[string]interface{}{
+				CodeLogParam:    c.Code,
+				MessageLogParam: c.RenderMessage(),
+				DetailsLogParam: getDetailsMarshalled(c.Details),
+			}
+		case *errors.MultiError:
+			details[i] = getMultiErrorMarshalled(c)
+		default:
+			details[i] = map[string]interface{}{
+				MessageLogParam: c.Error(),
 			}
 		}
-		return string(debug.Stack())
+	}
+	return map[string]interface{}{
+		CodeLogParam:    e.Code,
+		MessageLogParam: e.Message,
+		DetailsLogParam: details,
 	}
 }
 
-func withParams(ctx context.Context, event *zerolog.Event) *zerolog.Event {
-	if ctx == nil {
-		return event
-	}
-	mu.RLock()
-	defer mu.RUnlock()
-	for _, k := range p {
-		v := ctx.Value(k)
-		if v != nil {
-			event.Interface(k, v)
-		}
+// getDetailsMarshalled renders the errors in the details with just their message, unless they render themselves.
+func getDetailsMarshalled(details interface{}) interface{} {
+	switch d := details.(type) {
+	case *errors.Error, *errors.MultiError, json.Marshaler:
+		return d
+	case error:
+		return d.Error()
+	default:
+		return d
 	}
-	return event.Ctx(ctx)
 }
@@ -3,8 +3,11 @@
 package log
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"sync"
@@ -20,71 +23,92 @@ type cKey string
 func TestInitLoggerTraceLevel(t *testing.T) {
 	defer resetOnce()
 	InitLogger(TraceLevel, nil)
-	assert.Equal(t, zerolog.TraceLevel, zerolog.GlobalLevel())
+	assert.Equal(t, Level(TraceLevel), GetLevel())
 	InitLogger(TraceLevel, nil)
-	assert.Equal(t, zerolog.TraceLevel, zerolog.GlobalLevel())
+	assert.Equal(t, Level(TraceLevel), GetLevel())
 }
 
 func TestInitLoggerDebugLevel(t *testing.T) {
 	defer resetOnce()
 	InitLogger(DebugLevel, nil)
-	assert.Equal(t, zerolog.DebugLevel, zerolog.GlobalLevel())
+	assert.Equal(t, Level(DebugLevel), GetLevel())
 	InitLogger(TraceLevel, nil)
-	assert.Equal(t, zerolog.DebugLevel, zerolog.GlobalLevel())
+	assert.Equal(t, Level(DebugLevel), GetLevel())
 }
 
 func TestInitLoggerInfoLevel(t *testing.T) {
 	defer resetOnce()
 	InitLogger(InfoLevel, nil)
-	assert.Equal(t, zerolog.InfoLevel, zerolog.GlobalLevel())
+	assert.Equal(t, Level(InfoLevel), GetLevel())
 	InitLogger(TraceLevel, nil)
-	assert.Equal(t, zerolog.InfoLevel, zerolog.GlobalLevel())
+	assert.Equal(t, Level(InfoLevel), GetLevel())
 }
 
 func TestInitLoggerWarnLevel(t *testing.T) {
 	defer resetOnce()
 	InitLogger(WarnLevel, nil)
-	assert.Equal(t, zerolog.WarnLevel, zerolog.GlobalLevel())
+	assert.Equal(t, Level(WarnLevel), GetLevel())
 	InitLogger(TraceLevel, nil)
-	assert.Equal(t, zerolog.WarnLevel, zerolog.GlobalLevel())
+	assert.Equal(t, Level(WarnLevel), GetLevel())
 }
 
 func TestInitLoggerErrorLevel(t *testing.T) {
 	defer resetOnce()
 	InitLogger(ErrorLevel, nil)
-	assert.Equal(t, zerolog.ErrorLevel, zerolog.GlobalLevel())
+	assert.Equal(t, Level(ErrorLevel), GetLevel())
 	InitLogger(TraceLevel, nil)
-	assert.Equal(t, zerolog.ErrorLevel, zerolog.GlobalLevel())
+	assert.Equal(t, Level(ErrorLevel), GetLevel())
 }
 
 func TestInitLoggerFatalLevel(t *testing.T) {
 	defer resetOnce()
 	InitLogger(FatalLevel, nil)
-	assert.Equal(t, zerolog.FatalLevel, zerolog.GlobalLevel())
+	assert.Equal(t, Level(FatalLevel), GetLevel())
 	InitLogger(TraceLevel, nil)
-	assert.Equal(t, zerolog.FatalLevel, zerolog.GlobalLevel())
+	assert.Equal(t, Level(FatalLevel), GetLevel())
 }
 
 func TestInitLoggerPanicLevel(t *testing.T) {
 	defer resetOnce()
 	InitLogger(PanicLevel, nil)
-	assert.Equal(t, zerolog.PanicLevel, zerolog.GlobalLevel())
+	assert.Equal(t, Level(PanicLevel), GetLevel())
 	InitLogger(TraceLevel, nil)
-	assert.Equal(t, zerolog.PanicLevel, zerolog.GlobalLevel())
+	assert.Equal(t, Level(PanicLevel), GetLevel())
 }
 
 func TestInitLoggerIncorrectLevel(t *testing.T) {
 	defer resetOnce()
 	InitLogger("naruto", nil)
-	assert.Equal(t, zerolog.DebugLevel, zerolog.GlobalLevel())
+	assert.Equal(t, Level(DebugLevel), GetLevel())
 	InitLogger(TraceLevel, nil)
-	assert.Equal(t, zerolog.DebugLevel, zerolog.GlobalLevel())
+	assert.Equal(t, Level(DebugLevel), GetLevel())
+}
+
+func TestInitLoggerIndependentInstances(t *testing.T) {
+	defer resetOnce()
+	global := zerolog.GlobalLevel()
+	defer zerolog.SetGlobalLevel(global)
+	InitLogger(ErrorLevel, nil)
+	assert.Equal(t, global, zerolog.GlobalLevel())
+
+	// the global level of zerolog set by the application does not filter the events of the instances
+	zerolog.SetGlobalLevel(zerolog.InfoLevel)
+	var b bytes.Buffer
+	i := New(WithLevel(TraceLevel), WithWriter(&b))
+	i.Trace(context.Background()).Msg("naruto")
+	var m map[string]interface{}
+	assert.NoError(t, json.Unmarshal(b.Bytes(), &m))
+	assert.Equal(t, "trace", m[zerolog.LevelFieldName])
+	assert.Equal(t, "naruto", m[zerolog.MessageFieldName])
+	b.Reset()
+	Info(context.Background()).Msg("naruto")
+	assert.Empty(t, b.String())
 }
 
 func TestInitLoggerWithNilWriter(t *testing.T) {
 	defer resetOnce()
 	InitLoggerWithWriter(DebugLevel, nil, nil)
-	assert.Equal(t, zerolog.DebugLevel, zerolog.GlobalLevel())
+	assert.Equal(t, Level(DebugLevel), GetLevel())
 }
 
 func TestInitLoggerWithWriter(t *testing.T) {
@@ -97,14 +121,14 @@ func TestInitLoggerWithWriter(t *testing.T) {
 		assert.NoError(t, e)
 	}(fi)
 	InitLoggerWithWriter(DebugLevel, fi, nil)
-	assert.Equal(t, zerolog.DebugLevel, zerolog.GlobalLevel())
+	assert.Equal(t, Level(DebugLevel), GetLevel())
 }
 
 func TestInitLoggerWithParams(t *testing.T) {
 	defer resetOnce()
 	InitLogger(DebugLevel, []string{"naruto", "rocks"})
-	assert.Equal(t, zerolog.DebugLevel, zerolog.GlobalLevel())
-	assert.Equal(t, []string{"naruto", "rocks"}, p)
+	assert.Equal(t, Level(DebugLevel), GetLevel())
+	assert.Equal(t, []string{"naruto", "rocks"}, Default().params)
 }
 
 func TestLoggerMethods(t *testing.T) {
@@ -128,6 +152,133 @@ func TestLoggerErrorWarn(t *testing.T) {
 	ErrorWarn(context.Background(), nil)
 }
 
+func TestLoggerErrorWarnMultiError(t *testing.T) {
+	defer resetOnce()
+	var b bytes.Buffer
+	InitLoggerWithWriter(DebugLevel, &b, nil)
+	notFound := &errors.Error{StatusCode: http.StatusNotFound, Code: "NOT_FOUND"}
+	invalid := &errors.Error{StatusCode: http.StatusBadRequest, Code: "INVALID"}
+
+	ErrorWarn(context.Background(), errors.Join(invalid, notFound)).Send()
+	var m map[string]interface{}
+	assert.NoError(t, json.Unmarshal(b.Bytes(), &m))
+	assert.Equal(t, WarnLevel, m[zerolog.LevelFieldName])
+	assert.Equal(t, "2 errors occurred:\n\t* INVALID\n\t* NOT_FOUND", m[zerolog.ErrorFieldName])
+
+	b.Reset()
+	ErrorWarn(context.Background(), errors.Join(invalid, errors.Join(fmt.Errorf("naruto")))).Send()
+	m = nil
+	assert.NoError(t, json.Unmarshal(b.Bytes(), &m))
+	assert.Equal(t, ErrorLevel, m[zerolog.LevelFieldName])
+	assert.Equal(t, map[string]interface{}{
+		CodeLogParam:    "",
+		MessageLogParam: "",
+		DetailsLogParam: []interface{}{
+			map[string]interface{}{CodeLogParam: "INVALID", MessageLogParam: "", DetailsLogParam: nil},
+			map[string]interface{}{
+				CodeLogParam:    "",
+				MessageLogParam: "",
+				DetailsLogParam: []interface{}{map[string]interface{}{MessageLogParam: "naruto"}},
+			},
+		},
+	}, m[zerolog.ErrorStackFieldName])
+}
+
+func TestLoggerErrorWarnSeverity(t *testing.T) {
+	defer resetOnce()
+	var b bytes.Buffer
+	InitLoggerWithWriter(DebugLevel, &b, nil)
+
+	for _, c := range []struct {
+		err   error
+		level string
+	}{
+		{err: context.Canceled, level: InfoLevel},
+		{err: io.EOF, level: ErrorLevel},
+		{err: &errors.Error{StatusCode: http.StatusInternalServerError, Severity: errors.SeverityWarning}, level: WarnLevel},
+		{err: &errors.Error{StatusCode: http.StatusNotFound, Severity: errors.SeverityCritical}, level: ErrorLevel},
+		{err: errors.CategoryConflict.New("naruto"), level: WarnLevel},
+	} {
+		b.Reset()
+		ErrorWarn(context.Background(), c.err).Send()
+		var m map[string]interface{}
+		assert.NoError(t, json.Unmarshal(b.Bytes(), &m))
+		assert.Equal(t, c.level, m[zerolog.LevelFieldName])
+	}
+}
+
+func TestLoggerErrorTrace(t *testing.T) {
+	defer resetOnce()
+	var b bytes.Buffer
+	InitLoggerWithWriter(DebugLevel, &b, nil)
+
+	ErrorWarn(context.Background(), errors.New("naruto")).Send()
+	var m map[string]interface{}
+	assert.NoError(t, json.Unmarshal(b.Bytes(), &m))
+	stack, ok := m[zerolog.ErrorStackFieldName].(map[string]interface{})
+	assert.True(t, ok)
+	trace, ok := stack[TraceLogParam].([]interface{})
+	assert.True(t, ok)
+	assert.NotEmpty(t, trace)
+	frame, ok := trace[0].(map[string]interface{})
+	assert.True(t, ok)
+	assert.Contains(t, frame["function"], "TestLoggerErrorTrace")
+	assert.Contains(t, frame["file"], "log_test.go")
+	assert.Greater(t, frame["line"], float64(0))
+
+	b.Reset()
+	notFound := &errors.Error{StatusCode: http.StatusNotFound, Code: "NOT_FOUND"}
+	Error(context.Background()).Stack().Err(notFound.Wrap(fmt.Errorf("naruto"))).Send()
+	m = nil
+	assert.NoError(t, json.Unmarshal(b.Bytes(), &m))
+	assert.Equal(t, "naruto", m[zerolog.ErrorStackFieldName].(map[string]interface{})[DetailsLogParam])
+
+	b.Reset()
+	Error(context.Background()).Err(fmt.Errorf("batch: %w", errors.Join(notFound).(*errors.MultiError).
+		WithCode("BATCH"))).Send()
+	m = nil
+	assert.NoError(t, json.Unmarshal(b.Bytes(), &m))
+	assert.Equal(t, "BATCH", m[zerolog.ErrorStackFieldName].(map[string]interface{})[CodeLogParam])
+}
+
+func TestLoggerErrorFields(t *testing.T) {
+	defer resetOnce()
+	var b bytes.Buffer
+	InitLoggerWithWriter(DebugLevel, &b, nil)
+	notFound := &errors.Error{StatusCode: http.StatusNotFound, Code: "NOT_FOUND"}
+	err := fmt.Errorf("handler: %w", notFound.With("user", 42).With("operation", "get"))
+
+	ErrorWarn(context.Background(), err).Send()
+	var m map[string]interface{}
+	assert.NoError(t, json.Unmarshal(b.Bytes(), &m))
+	assert.Equal(t, map[string]interface{}{"user": 42.0, "operation": "get"}, m[FieldsLogParam])
+
+	b.Reset()
+	Error(context.Background()).Stack().Err(notFound.With("user", 42)).Send()
+	m = nil
+	assert.NoError(t, json.Unmarshal(b.Bytes(), &m))
+	assert.Equal(t, map[string]interface{}{"user": 42.0}, m[FieldsLogParam])
+	stack := m[zerolog.ErrorStackFieldName].(map[string]interface{})
+	assert.NotContains(t, stack, FieldsLogParam)
+
+	// the fields and the error are found in the chain at the levels enabling the stack trace
+	b.Reset()
+	Error(context.Background()).Err(err).Send()
+	m = nil
+	assert.NoError(t, json.Unmarshal(b.Bytes(), &m))
+	assert.Equal(t, map[string]interface{}{"user": 42.0, "operation": "get"}, m[FieldsLogParam])
+	stack = m[zerolog.ErrorStackFieldName].(map[string]interface{})
+	assert.Equal(t, "NOT_FOUND", stack[CodeLogParam])
+	assert.NotEmpty(t, stack[TraceLogParam])
+
+	b.Reset()
+	Error(context.Background()).Err(notFound).Send()
+	m = nil
+	assert.NoError(t, json.Unmarshal(b.Bytes(), &m))
+	_, ok := m[FieldsLogParam]
+	assert.False(t, ok)
+}
+
 func TestLoggerCapabilities(t *testing.T) {
 	defer resetOnce()
 	InitLogger(DebugLevel, []string{"naruto", "rocks"})
@@ -1,10 +1,17 @@
 package log
 
 import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"os"
+	"runtime"
 	"sync"
+	"time"
 
 	"github.com/rs/zerolog"
+	"github.com/sinhashubham95/go-utils/errors"
 )
 
 // Logger is the interface to the logging possibilities.
@@ -34,7 +41,8 @@ type Logger interface {
 	// JSON.
 	Bytes(key string, val []byte) Logger
 
-	// Err adds the field "error" with serialized err to the Logger context.
+	// Err adds the field "error" with serialized err to the Logger context, along with the fields attached to
+	// the errors in its tree, as per errors.Fields, whether the stack trace is enabled or not.
 	// If err is nil, no field is added.
 	Err(err error) Logger
 
@@ -147,8 +155,20 @@ type Logger interface {
 	Any(key string, a any) Logger
 }
 
+// l is the Logger building either the zerolog event, for the instances using zerolog, or the record, for the
+// other backends. Both are nil if the level of the event is disabled.
 type l struct {
-	e *zerolog.Event
+	i     *Instance
+	e     *zerolog.Event
+	r     *Record
+	ctx   context.Context
+	level zerolog.Level
+	stack bool
+	// sample tells whether the event is subject to the sampling of the instance
+	sample bool
+	// caller is the caller of the event if it is known beforehand, like for the events logged through slog
+	caller string
+	pc     uintptr
 }
 
 var lPool = &sync.Pool{
@@ -157,220 +177,487 @@ var lPool = &sync.Pool{
 	},
 }
 
-func newL(e *zerolog.Event) *l {
+func newL(i *Instance, ctx context.Context, level zerolog.Level, e *zerolog.Event, r *Record) *l {
 	x := lPool.Get().(*l)
+	x.i = i
 	x.e = e
+	x.r = r
+	x.ctx = ctx
+	x.level = level
+	x.stack = false
+	x.sample = true
+	x.caller = ""
+	x.pc = 0
 	return x
 }
 
 func disposeL(x *l) {
-	if x.e != nil {
-		x.e = nil
-	}
+	x.i = nil
+	x.e = nil
+	x.r = nil
+	x.ctx = nil
 	lPool.Put(x)
 }
 
+// the events are sent directly from the methods, so that the caller logged is the one calling them
+
 func (x *l) Msg(msg string) {
-	x.e.Msg(msg)
-	disposeL(x)
+	if x.enabled() {
+		pc, file, line, _ := runtime.Caller(1)
+		x.send(msg, pc, file, line)
+	}
+	x.dispose()
 }
 
 func (x *l) Msgf(format string, v ...interface{}) {
-	x.e.Msgf(format, v...)
-	disposeL(x)
+	if x.enabled() {
+		pc, file, line, _ := runtime.Caller(1)
+		x.send(fmt.Sprintf(format, v...), pc, file, line)
+	}
+	x.dispose()
 }
 
 func (x *l) Send() {
-	x.e.Send()
+	if x.enabled() {
+		pc, file, line, _ := runtime.Caller(1)
+		x.send("", pc, file, line)
+	}
+	x.dispose()
+}
+
+func (x *l) enabled() bool {
+	return x.e != nil || x.r != nil
+}
+
+// send samples the event, runs the hooks and writes the event, panicking or exiting afterwards for the panic and
+// the fatal levels, even if the event is dropped.
+func (x *l) send(msg string, pc uintptr, file string, line int) {
+	if x.sample && !x.i.sampling.sample(x.level, msg) {
+		// only the writing of the event is dropped, so that the flow of the program does not depend on the sampling
+		x.exit(msg)
+		return
+	}
+	for _, h := range x.i.hooks {
+		h(x.ctx, levelFromZeroLog(x.level), msg, x)
+	}
+	if x.caller == "" {
+		x.caller = zerolog.CallerMarshalFunc(pc, file, line)
+		x.pc = pc
+	}
+	if x.e != nil {
+		x.e.Str(zerolog.CallerFieldName, x.caller).Msg(msg)
+	} else {
+		x.r.Time = time.Now()
+		x.r.Message = msg
+		x.r.Caller = x.caller
+		x.r.pc = x.pc
+		x.i.backend.Write(x.r)
+	}
+	x.exit(msg)
+}
+
+// exit panics or exits for the panic and the fatal levels.
+func (x *l) exit(msg string) {
+	switch x.level {
+	case zerolog.PanicLevel:
+		panic(msg)
+	case zerolog.FatalLevel:
+		os.Exit(1)
+	}
+}
+
+// dispose reports the events dropped by sampling if it is time, and puts the Logger back in the pool.
+func (x *l) dispose() {
+	if x.sample {
+		x.i.reportDropped()
+	}
 	disposeL(x)
 }
 
-func (x *l) Bool(key string, b bool) Logger {
-	x.e.Bool(key, b)
+func (x *l) Err(err error) Logger {
+	if err != nil {
+		// the stack is marshalled here rather than by zerolog, so that the global marshaller of zerolog is not used
+		var stack interface{}
+		if x.stack && x.enabled() {
+			stack = getErrorStackMarshalled(err)
+		}
+		if x.r != nil {
+			x.r.add(zerolog.ErrorFieldName, err)
+			if stack != nil {
+				x.r.add(zerolog.ErrorStackFieldName, stack)
+			}
+		} else {
+			if stack != nil {
+				x.e.Interface(zerolog.ErrorStackFieldName, stack)
+			}
+			x.e.AnErr(zerolog.ErrorFieldName, err)
+		}
+	}
+	if fields := errors.Fields(err); fields != nil {
+		x.Interface(FieldsLogParam, fields)
+	}
 	return x
 }
 
-func (x *l) Bools(key string, b []bool) Logger {
-	x.e.Bools(key, b)
+func (x *l) Stack() Logger {
+	x.stack = true
 	return x
 }
 
-func (x *l) Bytes(key string, val []byte) Logger {
-	x.e.Bytes(key, val)
+func (x *l) Bool(key string, b bool) Logger {
+	if x.r != nil {
+		x.r.add(key, b)
+	} else {
+		x.e.Bool(key, b)
+	}
 	return x
 }
 
-func (x *l) Err(err error) Logger {
-	x.e.Err(err)
+func (x *l) Bools(key string, b []bool) Logger {
+	if x.r != nil {
+		x.r.add(key, b)
+	} else {
+		x.e.Bools(key, b)
+	}
+	return x
+}
+
+func (x *l) Bytes(key string, val []byte) Logger {
+	if x.r != nil {
+		x.r.add(key, string(val))
+	} else {
+		x.e.Bytes(key, val)
+	}
 	return x
 }
 
 func (x *l) Errs(key string, errs []error) Logger {
-	x.e.Errs(key, errs)
+	if x.r != nil {
+		x.r.add(key, errs)
+	} else {
+		x.e.Errs(key, errs)
+	}
 	return x
 }
 
 func (x *l) Float32(key string, f float32) Logger {
-	x.e.Float32(key, f)
+	if x.r != nil {
+		x.r.add(key, f)
+	} else {
+		x.e.Float32(key, f)
+	}
 	return x
 }
 
 func (x *l) Floats32(key string, f []float32) Logger {
-	x.e.Floats32(key, f)
+	if x.r != nil {
+		x.r.add(key, f)
+	} else {
+		x.e.Floats32(key, f)
+	}
 	return x
 }
 
 func (x *l) Float64(key string, f float64) Logger {
-	x.e.Float64(key, f)
+	if x.r != nil {
+		x.r.add(key, f)
+	} else {
+		x.e.Float64(key, f)
+	}
 	return x
 }
 
 func (x *l) Floats64(key string, f []float64) Logger {
-	x.e.Floats64(key, f)
+	if x.r != nil {
+		x.r.add(key, f)
+	} else {
+		x.e.Floats64(key, f)
+	}
 	return x
 }
 
 func (x *l) Hex(key string, val []byte) Logger {
-	x.e.Hex(key, val)
+	if x.r != nil {
+		x.r.add(key, hex.EncodeToString(val))
+	} else {
+		x.e.Hex(key, val)
+	}
 	return x
 }
 
 func (x *l) Int(key string, i int) Logger {
-	x.e.Int(key, i)
+	if x.r != nil {
+		x.r.add(key, i)
+	} else {
+		x.e.Int(key, i)
+	}
 	return x
 }
 
 func (x *l) Ints(key string, i []int) Logger {
-	x.e.Ints(key, i)
+	if x.r != nil {
+		x.r.add(key, i)
+	} else {
+		x.e.Ints(key, i)
+	}
 	return x
 }
 
 func (x *l) Int8(key string, i int8) Logger {
-	x.e.Int8(key, i)
+	if x.r != nil {
+		x.r.add(key, i)
+	} else {
+		x.e.Int8(key, i)
+	}
 	return x
 }
 
 func (x *l) Ints8(key string, i []int8) Logger {
-	x.e.Ints8(key, i)
+	if x.r != nil {
+		x.r.add(key, i)
+	} else {
+		x.e.Ints8(key, i)
+	}
 	return x
 }
 
 func (x *l) Int16(key string, i int16) Logger {
-	x.e.Int16(key, i)
+	if x.r != nil {
+		x.r.add(key, i)
+	} else {
+		x.e.Int16(key, i)
+	}
 	return x
 }
 
 func (x *l) Ints16(key string, i []int16) Logger {
-	x.e.Ints16(key, i)
+	if x.r != nil {
+		x.r.add(key, i)
+	} else {
+		x.e.Ints16(key, i)
+	}
 	return x
 }
 
 func (x *l) Int32(key string, i int32) Logger {
-	x.e.Int32(key, i)
+	if x.r != nil {
+		x.r.add(key, i)
+	} else {
+		x.e.Int32(key, i)
+	}
 	return x
 }
 
 func (x *l) Ints32(key string, i []int32) Logger {
-	x.e.Ints32(key, i)
+	if x.r != nil {
+		x.r.add(key, i)
+	} else {
+		x.e.Ints32(key, i)
+	}
 	return x
 }
 
 func (x *l) Int64(key string, i int64) Logger {
-	x.e.Int64(key, i)
+	if x.r != nil {
+		x.r.add(key, i)
+	} else {
+		x.e.Int64(key, i)
+	}
 	return x
 }
 
 func (x *l) Ints64(key string, i []int64) Logger {
-	x.e.Ints64(key, i)
+	if x.r != nil {
+		x.r.add(key, i)
+	} else {
+		x.e.Ints64(key, i)
+	}
 	return x
 }
 
 func (x *l) RawJSON(key string, b []byte) Logger {
-	x.e.RawJSON(key, b)
-	return x
-}
-
-func (x *l) Stack() Logger {
-	x.e.Stack()
+	if x.r != nil {
+		x.r.add(key, json.RawMessage(b))
+	} else {
+		x.e.RawJSON(key, b)
+	}
 	return x
 }
 
 func (x *l) Str(key, val string) Logger {
-	x.e.Str(key, val)
+	if x.r != nil {
+		x.r.add(key, val)
+	} else {
+		x.e.Str(key, val)
+	}
 	return x
 }
 
 func (x *l) Strs(key string, values []string) Logger {
-	x.e.Strs(key, values)
+	if x.r != nil {
+		x.r.add(key, values)
+	} else {
+		x.e.Strs(key, values)
+	}
 	return x
 }
 
 func (x *l) Stringer(key string, val fmt.Stringer) Logger {
-	x.e.Stringer(key, val)
+	if x.r != nil {
+		x.r.add(key, stringerValue(val))
+	} else {
+		x.e.Stringer(key, val)
+	}
 	return x
 }
 
 func (x *l) Stringers(key string, values []fmt.Stringer) Logger {
-	x.e.Stringers(key, values)
+	if x.r != nil {
+		x.r.add(key, stringersValue(values))
+	} else {
+		x.e.Stringers(key, values)
+	}
 	return x
 }
 
 func (x *l) Uint(key string, i uint) Logger {
-	x.e.Uint(key, i)
+	if x.r != nil {
+		x.r.add(key, i)
+	} else {
+		x.e.Uint(key, i)
+	}
 	return x
 }
 
 func (x *l) Uints(key string, i []uint) Logger {
-	x.e.Uints(key, i)
+	if x.r != nil {
+		x.r.add(key, i)
+	} else {
+		x.e.Uints(key, i)
+	}
 	return x
 }
 
 func (x *l) Uint8(key string, i uint8) Logger {
-	x.e.Uint8(key, i)
+	if x.r != nil {
+		x.r.add(key, i)
+	} else {
+		x.e.Uint8(key, i)
+	}
 	return x
 }
 
 func (x *l) Uints8(key string, i []uint8) Logger {
-	x.e.Uints8(key, i)
+	if x.r != nil {
+		x.r.add(key, i)
+	} else {
+		x.e.Uints8(key, i)
+	}
 	return x
 }
 
 func (x *l) Uint16(key string, i uint16) Logger {
-	x.e.Uint16(key, i)
+	if x.r != nil {
+		x.r.add(key, i)
+	} else {
+		x.e.Uint16(key, i)
+	}
 	return x
 }
 
 func (x *l) Uints16(key string, i []uint16) Logger {
-	x.e.Uints16(key, i)
+	if x.r != nil {
+		x.r.add(key, i)
+	} else {
+		x.e.Uints16(key, i)
+	}
 	return x
 }
 
 func (x *l) Uint32(key string, i uint32) Logger {
-	x.e.Uint32(key, i)
+	if x.r != nil {
+		x.r.add(key, i)
+	} else {
+		x.e.Uint32(key, i)
+	}
 	return x
 }
 
 func (x *l) Uints32(key string, i []uint32) Logger {
-	x.e.Uints32(key, i)
+	if x.r != nil {
+		x.r.add(key, i)
+	} else {
+		x.e.Uints32(key, i)
+	}
 	return x
 }
 
 func (x *l) Uint64(key string, i uint64) Logger {
-	x.e.Uint64(key, i)
+	if x.r != nil {
+		x.r.add(key, i)
+	} else {
+		x.e.Uint64(key, i)
+	}
 	return x
 }
 
 func (x *l) Uints64(key string, i []uint64) Logger {
-	x.e.Uints64(key, i)
+	if x.r != nil {
+		x.r.add(key, i)
+	} else {
+		x.e.Uints64(key, i)
+	}
 	return x
 }
 
 func (x *l) Interface(key string, i interface{}) Logger {
-	x.e.Interface(key, i)
+	if x.r != nil {
+		x.r.add(key, i)
+	} else {
+		x.e.Interface(key, i)
+	}
 	return x
 }
 
 func (x *l) Any(key string, a any) Logger {
-	x.e.Any(key, a)
+	if x.r != nil {
+		x.r.add(key, a)
+	} else {
+		x.e.Any(key, a)
+	}
 	return x
 }
+
+// any adds the value of any type, with the errors, the durations and the times written by zerolog the same as when
+// they are added using their own methods.
+func (x *l) any(key string, value interface{}) {
+	if x.r != nil {
+		x.r.add(key, value)
+		return
+	}
+	switch v := value.(type) {
+	case error:
+		x.e.AnErr(key, v)
+	case time.Duration:
+		x.e.Dur(key, v)
+	case time.Time:
+		x.e.Time(key, v)
+	default:
+		x.e.Interface(key, v)
+	}
+}
+
+func stringerValue(val fmt.Stringer) interface{} {
+	if val == nil {
+		return nil
+	}
+	return val.String()
+}
+
+func stringersValue(values []fmt.Stringer) []interface{} {
+	r := make([]interface{}, len(values))
+	for i, v := range values {
+		r[i] = stringerValue(v)
+	}
+	return r
+}
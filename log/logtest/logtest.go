@@ -0,0 +1,115 @@
+// Package logtest provides the logger instances recording the events in the memory, to assert on the events logged
+// in the tests.
+package logtest
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/sinhashubham95/go-utils/errors"
+	"github.com/sinhashubham95/go-utils/log"
+)
+
+// Logger is the logger instance recording the events it logs, along with the events logged by its components.
+//
+// Each test creates its own logger, and passes it to the code under test, so that the tests running in parallel
+// record their events separately, or sets it as the default instance using Default for the code logging using the
+// package level functions. It is safe for concurrent use.
+type Logger struct {
+	*log.Instance
+	recorder *log.Recorder
+}
+
+// Entry is the event recorded.
+type Entry struct {
+	// Level is the level of the event.
+	Level log.Level
+	// Message is the message of the event.
+	Message string
+	// Fields is the fields of the event, including the component and the params in the context.
+	Fields map[string]interface{}
+	// Caller is the file and the line from which the event was logged, in the format file:line.
+	Caller string
+}
+
+// New is used to create the logger recording the events of all the levels, unless the level is set using the
+// options. The options setting the writer or the backend are ignored.
+func New(opts ...log.Option) *Logger {
+	r := log.NewRecorder()
+	opts = append([]log.Option{log.WithLevel(log.TraceLevel)}, opts...)
+	return &Logger{Instance: log.New(append(opts, log.WithBackend(r))...), recorder: r}
+}
+
+// Default is used to create the logger recording the events, the same as New, and set it as the default instance
+// until the test ends, so that the events logged using the package level functions and components are recorded.
+// The previous default instance is restored when the test ends. The tests using it must not run in parallel, since
+// the default instance is shared by all of them.
+func Default(t testing.TB, opts ...log.Option) *Logger {
+	t.Helper()
+	l := New(opts...)
+	previous := log.Default()
+	log.SetDefault(l.Instance)
+	t.Cleanup(func() {
+		log.SetDefault(previous)
+	})
+	return l
+}
+
+// Entries is used to get the events recorded, in the order in which they were logged.
+func (l *Logger) Entries() []Entry {
+	records := l.recorder.Records()
+	r := make([]Entry, len(records))
+	for i, rec := range records {
+		r[i] = Entry{Level: rec.Level, Message: rec.Message, Fields: rec.FieldsMap(), Caller: rec.Caller}
+	}
+	return r
+}
+
+// HasEntry is used to check whether an event with the level and the message is recorded, having the fields given as
+// the key value pairs, for example HasEntry(log.InfoLevel, "request", "path", "/users", "status", 200).
+// The event can have other fields as well. The values of the fields are compared using reflect.DeepEqual, other than
+// the errors, which are compared using errors.Is, so the fields must have the same types as the ones logged, like
+// int64 for the integers logged using Int64.
+//
+// It panics if the fields are not key value pairs with the string keys.
+func (l *Logger) HasEntry(level log.Level, msg string, fields ...interface{}) bool {
+	if len(fields)%2 != 0 {
+		panic(fmt.Sprintf("fields must be key value pairs, got %d values", len(fields)))
+	}
+	for i := 0; i < len(fields); i += 2 {
+		if _, ok := fields[i].(string); !ok {
+			panic(fmt.Sprintf("field key must be a string, got %T", fields[i]))
+		}
+	}
+	for _, e := range l.Entries() {
+		if e.Level == level && e.Message == msg && e.hasFields(fields) {
+			return true
+		}
+	}
+	return false
+}
+
+// Reset is used to remove the events recorded.
+func (l *Logger) Reset() {
+	l.recorder.Reset()
+}
+
+func (e Entry) hasFields(fields []interface{}) bool {
+	for i := 0; i < len(fields); i += 2 {
+		v, ok := e.Fields[fields[i].(string)]
+		if !ok || !equal(v, fields[i+1]) {
+			return false
+		}
+	}
+	return true
+}
+
+func equal(actual, expected interface{}) bool {
+	if err, ok := actual.(error); ok {
+		if target, ok := expected.(error); ok {
+			return errors.Is(err, target)
+		}
+	}
+	return reflect.DeepEqual(actual, expected)
+}
@@ -0,0 +1,86 @@
+package logtest_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/sinhashubham95/go-utils/errors"
+	"github.com/sinhashubham95/go-utils/log"
+	"github.com/sinhashubham95/go-utils/log/logtest"
+	"github.com/stretchr/testify/assert"
+)
+
+var errNotFound = &errors.Error{Code: "NOT_FOUND"}
+
+func TestNew(t *testing.T) {
+	l := logtest.New(log.WithParams("request"))
+	ctx := context.WithValue(context.Background(), "request", "naruto")
+
+	l.Trace(ctx).Msg("trace")
+	l.Component("db").Warn(ctx).Int("rows", 2).Err(errNotFound.WithMessage("user not found")).Msg("query failed")
+
+	entries := l.Entries()
+	assert.Len(t, entries, 2)
+	assert.Equal(t, log.Level(log.TraceLevel), entries[0].Level)
+	assert.Equal(t, "trace", entries[0].Message)
+	assert.Equal(t, map[string]interface{}{"request": "naruto"}, entries[0].Fields)
+	assert.Contains(t, entries[0].Caller, "logtest_test.go")
+
+	assert.True(t, l.HasEntry(log.TraceLevel, "trace"))
+	assert.True(t, l.HasEntry(log.WarnLevel, "query failed", "rows", 2, log.ComponentLogParam, "db"))
+	assert.True(t, l.HasEntry(log.WarnLevel, "query failed", "error", errNotFound))
+	assert.False(t, l.HasEntry(log.WarnLevel, "query failed", "rows", int64(2)))
+	assert.False(t, l.HasEntry(log.WarnLevel, "query failed", "missing", nil))
+	assert.False(t, l.HasEntry(log.ErrorLevel, "query failed"))
+	assert.False(t, l.HasEntry(log.WarnLevel, "other"))
+
+	assert.Panics(t, func() { l.HasEntry(log.WarnLevel, "query failed", "rows") })
+	assert.Panics(t, func() { l.HasEntry(log.WarnLevel, "query failed", 1, 2) })
+
+	l.Reset()
+	assert.Empty(t, l.Entries())
+	assert.False(t, l.HasEntry(log.TraceLevel, "trace"))
+}
+
+func TestNewLevel(t *testing.T) {
+	l := logtest.New(log.WithLevel(log.InfoLevel))
+	l.Debug(context.Background()).Msg("ignored")
+	l.Info(context.Background()).Msg("logged")
+	assert.Len(t, l.Entries(), 1)
+	assert.True(t, l.HasEntry(log.InfoLevel, "logged"))
+}
+
+func TestDefault(t *testing.T) {
+	original := log.Default()
+	db := log.Component("db")
+	t.Run("default", func(t *testing.T) {
+		l := logtest.Default(t, log.WithLevel(log.InfoLevel))
+		assert.Same(t, l.Instance, log.Default())
+		log.Debug(context.Background()).Msg("ignored")
+		log.Info(context.Background()).Msg("logged")
+		db.Warn(context.Background()).Msg("slow query")
+		assert.Len(t, l.Entries(), 2)
+		assert.True(t, l.HasEntry(log.InfoLevel, "logged"))
+		assert.True(t, l.HasEntry(log.WarnLevel, "slow query", log.ComponentLogParam, "db"))
+	})
+	assert.Same(t, original, log.Default())
+}
+
+func TestParallel(t *testing.T) {
+	for n := 0; n < 4; n += 1 {
+		n := n
+		t.Run(fmt.Sprint(n), func(t *testing.T) {
+			t.Parallel()
+			l := logtest.New()
+			for i := 0; i < 10; i += 1 {
+				l.Info(context.Background()).Int("test", n).Msg("hello")
+			}
+			entries := l.Entries()
+			assert.Len(t, entries, 10)
+			for _, e := range entries {
+				assert.Equal(t, n, e.Fields["test"])
+			}
+		})
+	}
+}
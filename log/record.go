@@ -0,0 +1,95 @@
+package log
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Backend is the destination of the events logged by an instance, like the JSON written using zerolog,
+// the handler of log/slog or the memory.
+type Backend interface {
+	// Write is used to write the record of the event. The record must not be retained after it returns.
+	Write(r *Record)
+}
+
+// Record is the event logged, passed to the backend.
+type Record struct {
+	// Time is the time at which the event was logged.
+	Time time.Time
+	// Level is the level of the event.
+	Level Level
+	// Message is the message of the event.
+	Message string
+	// Fields is the fields added to the event, in the order in which they were added.
+	Fields []Field
+	// Caller is the file and the line from which the event was logged, in the format file:line.
+	Caller string
+	// Context is the context with which the event was logged.
+	Context context.Context
+	// pc is the program counter of the caller
+	pc uintptr
+}
+
+// Field is a key value pair added to the event.
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// Field is used to get the value of the field with the key, the last one added if there are many.
+func (r *Record) Field(key string) (interface{}, bool) {
+	for i := len(r.Fields) - 1; i >= 0; i -= 1 {
+		if r.Fields[i].Key == key {
+			return r.Fields[i].Value, true
+		}
+	}
+	return nil, false
+}
+
+// FieldsMap is used to get the fields of the event as a map, with the value of the last one added for each key.
+func (r *Record) FieldsMap() map[string]interface{} {
+	m := make(map[string]interface{}, len(r.Fields))
+	for _, f := range r.Fields {
+		m[f.Key] = f.Value
+	}
+	return m
+}
+
+func (r *Record) add(key string, value interface{}) {
+	r.Fields = append(r.Fields, Field{Key: key, Value: value})
+}
+
+// Recorder is the backend keeping the records in the memory, to be inspected later, for example in the tests.
+type Recorder struct {
+	mu      sync.RWMutex
+	records []Record
+}
+
+// NewRecorder is used to create the recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// Write is used to keep the record.
+func (r *Recorder) Write(record *Record) {
+	c := *record
+	c.Fields = append([]Field(nil), record.Fields...)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records = append(r.records, c)
+}
+
+// Records is used to get the records kept, in the order in which they were written.
+func (r *Recorder) Records() []Record {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]Record(nil), r.records...)
+}
+
+// Reset is used to remove the records kept.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records = nil
+}
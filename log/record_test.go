@@ -0,0 +1,148 @@
+package log_test
+
+import (
+	"context"
+	"encoding/json"
+	stdErrors "errors"
+	"sync"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/sinhashubham95/go-utils/errors"
+	"github.com/sinhashubham95/go-utils/log"
+	"github.com/stretchr/testify/assert"
+)
+
+type name string
+
+func (n name) String() string {
+	return string(n)
+}
+
+func TestRecorder(t *testing.T) {
+	r := log.NewRecorder()
+	var hooked []string
+	i := log.New(log.WithLevel(log.InfoLevel), log.WithBackend(r), log.WithParams("request"),
+		log.WithHooks(func(ctx context.Context, level log.Level, msg string, logger log.Logger) {
+			hooked = append(hooked, msg)
+			logger.Bool("hooked", true)
+		}))
+	ctx := context.WithValue(context.Background(), "request", "naruto")
+
+	i.Debug(ctx).Msg("ignored")
+	i.Component("db").Info(ctx).Str("query", "select").Int("rows", 2).Bytes("raw", []byte("ok")).
+		Hex("hex", []byte{1, 255}).RawJSON("json", []byte(`{"a":1}`)).Stringer("name", name("sasuke")).
+		Stringer("nil", nil).Msgf("query %d", 1)
+
+	records := r.Records()
+	assert.Len(t, records, 1)
+	assert.Equal(t, log.Level(log.InfoLevel), records[0].Level)
+	assert.Equal(t, "query 1", records[0].Message)
+	assert.Equal(t, ctx, records[0].Context)
+	assert.False(t, records[0].Time.IsZero())
+	assert.Contains(t, records[0].Caller, "record_test.go")
+	assert.Equal(t, map[string]interface{}{
+		log.ComponentLogParam: "db",
+		"request":             "naruto",
+		"query":               "select",
+		"rows":                2,
+		"raw":                 "ok",
+		"hex":                 "01ff",
+		"json":                json.RawMessage(`{"a":1}`),
+		"name":                "sasuke",
+		"nil":                 nil,
+		"hooked":              true,
+	}, records[0].FieldsMap())
+	assert.Equal(t, []string{"query 1"}, hooked)
+
+	v, ok := records[0].Field("query")
+	assert.True(t, ok)
+	assert.Equal(t, "select", v)
+	_, ok = records[0].Field("missing")
+	assert.False(t, ok)
+
+	r.Reset()
+	assert.Empty(t, r.Records())
+}
+
+func TestRecorderErrors(t *testing.T) {
+	r := log.NewRecorder()
+	i := log.New(log.WithBackend(r))
+	err := errors.New("failed").(*errors.Error).With("user", "naruto")
+
+	i.Warn(context.Background()).Err(err).Send()
+	i.Error(context.Background()).Err(err).Send()
+	i.Info(context.Background()).Err(nil).Send()
+
+	records := r.Records()
+	assert.Len(t, records, 3)
+	fields := records[0].FieldsMap()
+	assert.Equal(t, err, fields[zerolog.ErrorFieldName])
+	assert.Equal(t, map[string]interface{}{"user": "naruto"}, fields[log.FieldsLogParam])
+	fields = records[1].FieldsMap()
+	assert.Equal(t, err, fields[zerolog.ErrorFieldName])
+	assert.Contains(t, fields, zerolog.ErrorStackFieldName)
+	assert.Equal(t, map[string]interface{}{"user": "naruto"}, fields[log.FieldsLogParam])
+	assert.Empty(t, records[2].Fields)
+}
+
+func TestRecorderPanic(t *testing.T) {
+	r := log.NewRecorder()
+	i := log.New(log.WithBackend(r))
+	assert.PanicsWithValue(t, "boom", func() {
+		i.Panic(context.Background()).Msg("boom")
+	})
+	assert.Len(t, r.Records(), 1)
+	assert.Equal(t, log.Level(log.PanicLevel), r.Records()[0].Level)
+}
+
+func TestRecorderConcurrent(t *testing.T) {
+	r := log.NewRecorder()
+	i := log.New(log.WithBackend(r))
+	var wg sync.WaitGroup
+	for n := 0; n < 10; n += 1 {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			i.Info(context.Background()).Int("n", n).Send()
+		}(n)
+	}
+	wg.Wait()
+	assert.Len(t, r.Records(), 10)
+}
+
+func TestZerologBackend(t *testing.T) {
+	var b syncBuffer
+	i := log.New(log.WithBackend(log.NewZerologBackend(&b)))
+	i.Info(context.Background()).Err(stdErrors.New("failed")).Str("key", "value").Msg("hello")
+
+	lines := b.lines(t)
+	assert.Len(t, lines, 1)
+	assert.Equal(t, "hello", lines[0][zerolog.MessageFieldName])
+	assert.Equal(t, "failed", lines[0][zerolog.ErrorFieldName])
+	assert.Equal(t, "value", lines[0]["key"])
+	assert.Contains(t, lines[0][zerolog.CallerFieldName], "record_test.go")
+	assert.Contains(t, lines[0], zerolog.TimestampFieldName)
+}
+
+type writeBackend struct {
+	log.Backend
+}
+
+func TestBackendWrite(t *testing.T) {
+	// the zerolog backend wrapped in another backend gets the records rather than the events
+	var b syncBuffer
+	i := log.New(log.WithBackend(writeBackend{Backend: log.NewZerologBackend(&b)}))
+	i.Warn(context.Background()).Err(stdErrors.New("failed")).Errs("errs", []error{stdErrors.New("a")}).
+		Ints("ints", []int{1, 2}).Msg("hello")
+
+	lines := b.lines(t)
+	assert.Len(t, lines, 1)
+	assert.Equal(t, "hello", lines[0][zerolog.MessageFieldName])
+	assert.Equal(t, "warn", lines[0][zerolog.LevelFieldName])
+	assert.Equal(t, "failed", lines[0][zerolog.ErrorFieldName])
+	assert.Equal(t, []interface{}{"a"}, lines[0]["errs"])
+	assert.Equal(t, []interface{}{float64(1), float64(2)}, lines[0]["ints"])
+	assert.Contains(t, lines[0][zerolog.CallerFieldName], "record_test.go")
+	assert.Contains(t, lines[0], zerolog.TimestampFieldName)
+}
@@ -0,0 +1,191 @@
+package log
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// DefaultSamplingReportPeriod is the default period after which the number of the events dropped by sampling is logged.
+const DefaultSamplingReportPeriod = time.Minute
+
+// Sampler is used to decide whether the event with the message is logged or dropped.
+type Sampler interface {
+	// Sample returns whether the event with the message is logged.
+	Sample(msg string) bool
+}
+
+// SamplerFunc is the function deciding whether the event with the message is logged, used as a Sampler.
+type SamplerFunc func(msg string) bool
+
+// Sample returns whether the event with the message is logged.
+func (f SamplerFunc) Sample(msg string) bool {
+	return f(msg)
+}
+
+type everyNSampler struct {
+	n       uint32
+	counter uint32
+}
+
+// EveryN is used to create the sampler logging 1 in n events, starting with the first one.
+// It panics if n is 0.
+func EveryN(n uint32) Sampler {
+	if n == 0 {
+		panic("sampling n must be at least 1")
+	}
+	return &everyNSampler{n: n}
+}
+
+func (s *everyNSampler) Sample(string) bool {
+	return (atomic.AddUint32(&s.counter, 1)-1)%s.n == 0
+}
+
+type burstSampler struct {
+	mu     sync.Mutex
+	burst  uint32
+	period time.Duration
+	next   Sampler
+	count  uint32
+	resets time.Time
+	now    func() time.Time
+}
+
+// Burst is used to create the sampler logging the first burst events in each period, and deciding for the rest of
+// the events in the period using next, which drops them if it is nil. For example, Burst(10, time.Second, EveryN(100))
+// logs 10 events per second, and 1 in 100 of the events beyond them.
+// It panics if the period is not positive.
+func Burst(burst uint32, period time.Duration, next Sampler) Sampler {
+	if period <= 0 {
+		panic(fmt.Sprintf("sampling period must be positive, got %s", period))
+	}
+	return &burstSampler{burst: burst, period: period, next: next, now: time.Now}
+}
+
+func (s *burstSampler) Sample(msg string) bool {
+	s.mu.Lock()
+	now := s.now()
+	if !now.Before(s.resets) {
+		s.count = 0
+		s.resets = now.Add(s.period)
+	}
+	s.count += 1
+	allowed := s.count <= s.burst
+	s.mu.Unlock()
+	if allowed {
+		return true
+	}
+	return s.next != nil && s.next.Sample(msg)
+}
+
+type perKeySampler struct {
+	mu     sync.Mutex
+	limit  uint32
+	period time.Duration
+	key    func(msg string) string
+	counts map[string]uint32
+	resets time.Time
+	now    func() time.Time
+}
+
+// PerKey is used to create the sampler logging at most limit events with the same key in each period, with the key
+// of the events derived from their messages using key, or the message itself if it is nil. So the events logged
+// repeatedly, like the same failure for every request, are limited without dropping the other events.
+// It panics if the period is not positive.
+func PerKey(limit uint32, period time.Duration, key func(msg string) string) Sampler {
+	if period <= 0 {
+		panic(fmt.Sprintf("sampling period must be positive, got %s", period))
+	}
+	return &perKeySampler{limit: limit, period: period, key: key, counts: map[string]uint32{}, now: time.Now}
+}
+
+func (s *perKeySampler) Sample(msg string) bool {
+	k := msg
+	if s.key != nil {
+		k = s.key(msg)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// the counts of all the keys are reset together, so that the keys not logged anymore are not kept
+	if now := s.now(); !now.Before(s.resets) {
+		s.counts = map[string]uint32{}
+		s.resets = now.Add(s.period)
+	}
+	s.counts[k] += 1
+	return s.counts[k] <= s.limit
+}
+
+// sampling is the samplers of the levels of an instance, shared by its components, along with the number of the
+// events dropped by them, which is logged periodically.
+type sampling struct {
+	samplers map[zerolog.Level]Sampler
+	dropped  map[zerolog.Level]*uint64
+	period   time.Duration
+	reported int64
+	now      func() time.Time
+}
+
+func newSampling(samplers map[zerolog.Level]Sampler, period time.Duration) *sampling {
+	if len(samplers) == 0 {
+		return nil
+	}
+	s := &sampling{samplers: samplers, dropped: make(map[zerolog.Level]*uint64, len(samplers)), period: period,
+		now: time.Now}
+	s.reported = s.now().UnixNano()
+	for l := range samplers {
+		s.dropped[l] = new(uint64)
+	}
+	return s
+}
+
+// sample returns whether the event of the level with the message is logged, counting it if it is dropped.
+func (s *sampling) sample(level zerolog.Level, msg string) bool {
+	if s == nil {
+		return true
+	}
+	sampler, ok := s.samplers[level]
+	if !ok {
+		return true
+	}
+	if sampler.Sample(msg) {
+		return true
+	}
+	atomic.AddUint64(s.dropped[level], 1)
+	return false
+}
+
+// report returns the number of the events dropped for each level since the last report, if the period has elapsed
+// since it, and nil otherwise.
+func (s *sampling) report() map[string]uint64 {
+	if s == nil || s.period <= 0 {
+		return nil
+	}
+	now := s.now().UnixNano()
+	last := atomic.LoadInt64(&s.reported)
+	if now-last < int64(s.period) || !atomic.CompareAndSwapInt64(&s.reported, last, now) {
+		return nil
+	}
+	dropped := make(map[string]uint64, len(s.dropped))
+	for l, c := range s.dropped {
+		if n := atomic.SwapUint64(c, 0); n > 0 {
+			dropped[string(levelFromZeroLog(l))] = n
+		}
+	}
+	return dropped
+}
+
+// Dropped is used to get the number of the events dropped by sampling for each level since they were last logged.
+func (i *Instance) Dropped() map[Level]uint64 {
+	i = i.get()
+	r := map[Level]uint64{}
+	if i.sampling == nil {
+		return r
+	}
+	for l, c := range i.sampling.dropped {
+		r[levelFromZeroLog(l)] = atomic.LoadUint64(c)
+	}
+	return r
+}
@@ -0,0 +1,191 @@
+package log_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/sinhashubham95/go-utils/log"
+	"github.com/stretchr/testify/assert"
+)
+
+// clock is the time advanced by the tests.
+type clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newClock() *clock {
+	return &clock{now: time.Unix(0, 0)}
+}
+
+func (c *clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func sampled(s log.Sampler, msgs ...string) []bool {
+	r := make([]bool, len(msgs))
+	for i, m := range msgs {
+		r[i] = s.Sample(m)
+	}
+	return r
+}
+
+func TestEveryN(t *testing.T) {
+	assert.Equal(t, []bool{true, false, false, true, false, false, true}, sampled(log.EveryN(3),
+		"a", "a", "a", "a", "a", "a", "a"))
+	assert.Equal(t, []bool{true, true}, sampled(log.EveryN(1), "a", "a"))
+	assert.Panics(t, func() { log.EveryN(0) })
+}
+
+func TestBurst(t *testing.T) {
+	c := newClock()
+	s := log.Burst(2, time.Second, nil)
+	log.SetSamplerClock(s, c.Now)
+	assert.Equal(t, []bool{true, true, false}, sampled(s, "a", "b", "c"))
+	c.Advance(time.Second - 1)
+	assert.Equal(t, []bool{false}, sampled(s, "d"))
+	c.Advance(1)
+	assert.Equal(t, []bool{true, true, false}, sampled(s, "a", "b", "c"))
+
+	s = log.Burst(1, time.Hour, log.EveryN(2))
+	assert.Equal(t, []bool{true, true, false, true, false}, sampled(s, "a", "b", "c", "d", "e"))
+	assert.Panics(t, func() { log.Burst(1, 0, nil) })
+}
+
+func TestPerKey(t *testing.T) {
+	c := newClock()
+	s := log.PerKey(2, time.Second, nil)
+	log.SetSamplerClock(s, c.Now)
+	assert.Equal(t, []bool{true, true, true, false, true, false}, sampled(s, "a", "a", "b", "a", "b", "b"))
+	c.Advance(time.Second - 1)
+	assert.Equal(t, []bool{false}, sampled(s, "a"))
+	c.Advance(1)
+	assert.Equal(t, []bool{true, true, false}, sampled(s, "a", "a", "a"))
+
+	s = log.PerKey(1, time.Hour, func(msg string) string {
+		return strings.SplitN(msg, ":", 2)[0]
+	})
+	assert.Equal(t, []bool{true, false, true}, sampled(s, "timeout: 1", "timeout: 2", "refused: 1"))
+	assert.Panics(t, func() { log.PerKey(1, -time.Second, nil) })
+}
+
+func TestWithSampler(t *testing.T) {
+	var b syncBuffer
+	i := log.New(log.WithWriter(&b), log.WithSampler(log.EveryN(2)),
+		log.WithSampler(log.PerKey(1, time.Hour, nil), log.WarnLevel), log.WithSamplingReport(0))
+	db := i.Component("db")
+	for n := 0; n < 4; n += 1 {
+		i.Info(context.Background()).Msgf("info %d", n)
+		db.Warn(context.Background()).Msg("slow")
+		i.Error(context.Background()).Send()
+	}
+	i.Debug(context.Background()).Send()
+
+	lines := b.lines(t)
+	var info, warn, errs, debug int
+	for _, m := range lines {
+		switch m[zerolog.LevelFieldName] {
+		case log.InfoLevel:
+			info += 1
+		case log.WarnLevel:
+			warn += 1
+		case log.ErrorLevel:
+			errs += 1
+		case log.DebugLevel:
+			debug += 1
+		}
+	}
+	assert.Equal(t, 2, info)
+	assert.Equal(t, 1, warn)
+	assert.Equal(t, 4, errs)
+	assert.Equal(t, 1, debug)
+	assert.Equal(t, map[log.Level]uint64{log.TraceLevel: 0, log.DebugLevel: 0, log.InfoLevel: 2, log.WarnLevel: 3},
+		i.Dropped())
+	assert.Equal(t, map[log.Level]uint64{}, log.New().Dropped())
+}
+
+func TestWithSamplerErrors(t *testing.T) {
+	var b syncBuffer
+	i := log.New(log.WithWriter(&b), log.WithSampler(log.EveryN(2), log.ErrorLevel))
+	for n := 0; n < 4; n += 1 {
+		i.Error(context.Background()).Send()
+	}
+	assert.Len(t, b.lines(t), 2)
+}
+
+func TestSamplingReport(t *testing.T) {
+	var b syncBuffer
+	i := log.New(log.WithWriter(&b), log.WithSampler(log.EveryN(10), log.InfoLevel),
+		log.WithSamplingReport(time.Minute))
+	c := newClock()
+	log.SetSamplingClock(i, c.Now)
+	for n := 0; n < 5; n += 1 {
+		i.Info(context.Background()).Msg("naruto")
+	}
+	c.Advance(time.Minute - 1)
+	i.Info(context.Background()).Msg("naruto")
+	assert.Len(t, b.lines(t), 1)
+	c.Advance(1)
+	i.Info(context.Background()).Msg("naruto")
+
+	lines := b.lines(t)
+	assert.Len(t, lines, 2)
+	assert.Equal(t, "log events dropped by sampling", lines[1][zerolog.MessageFieldName])
+	assert.Equal(t, map[string]interface{}{"info": float64(6)}, lines[1][log.DroppedLogParam])
+	assert.Equal(t, uint64(0), i.Dropped()[log.InfoLevel])
+}
+
+func TestSamplingPanic(t *testing.T) {
+	var b syncBuffer
+	i := log.New(log.WithWriter(&b), log.WithSampler(log.SamplerFunc(func(string) bool { return false }),
+		log.PanicLevel))
+	assert.PanicsWithValue(t, "naruto", func() { i.Panic(context.Background()).Msg("naruto") })
+	assert.Empty(t, b.lines(t))
+	assert.Equal(t, uint64(1), i.Dropped()[log.PanicLevel])
+}
+
+func TestSamplingFatal(t *testing.T) {
+	if os.Getenv("LOG_SAMPLING_FATAL") == "1" {
+		i := log.New(log.WithWriter(os.Stdout), log.WithSampler(log.SamplerFunc(func(string) bool { return false }),
+			log.FatalLevel))
+		i.Fatal(context.Background()).Msg("naruto")
+		return
+	}
+	cmd := exec.Command(os.Args[0], "-test.run=^TestSamplingFatal$")
+	cmd.Env = append(os.Environ(), "LOG_SAMPLING_FATAL=1")
+	out, err := cmd.Output()
+	var exitErr *exec.ExitError
+	assert.True(t, errors.As(err, &exitErr))
+	assert.Equal(t, 1, exitErr.ExitCode())
+	assert.NotContains(t, string(out), "naruto")
+}
+
+func TestSamplingConcurrent(t *testing.T) {
+	var b syncBuffer
+	i := log.New(log.WithWriter(&b), log.WithSampler(log.Burst(5, time.Hour, log.PerKey(1, time.Hour, nil))),
+		log.WithSamplingReport(time.Nanosecond))
+	var wg sync.WaitGroup
+	for n := 0; n < 10; n += 1 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			i.Info(context.Background()).Msg("naruto")
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,211 @@
+//go:build go1.21
+
+package log
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+
+	"github.com/rs/zerolog"
+)
+
+// levels of log/slog corresponding to the levels not defined by it
+const (
+	slogTraceLevel = slog.LevelDebug - 4
+	slogFatalLevel = slog.LevelError + 4
+	slogPanicLevel = slog.LevelError + 8
+)
+
+// slogBackend is the backend writing the events to the handler of log/slog.
+type slogBackend struct {
+	handler slog.Handler
+}
+
+// NewSlogBackend is used to create the backend writing the events to the handler of log/slog, for example
+// NewSlogBackend(slog.NewJSONHandler(os.Stdout, nil)). The trace, fatal and panic levels, which are not defined by
+// slog, are written as slog.LevelDebug-4, slog.LevelError+4 and slog.LevelError+8 respectively.
+// It panics if the handler is nil.
+func NewSlogBackend(h slog.Handler) Backend {
+	if h == nil {
+		panic("slog handler must not be nil")
+	}
+	return &slogBackend{handler: h}
+}
+
+// Write is used to write the record to the handler.
+func (b *slogBackend) Write(r *Record) {
+	ctx := r.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	level := slogLevel(r.Level)
+	if !b.handler.Enabled(ctx, level) {
+		return
+	}
+	rec := slog.NewRecord(r.Time, level, r.Message, r.pc)
+	for _, f := range r.Fields {
+		rec.AddAttrs(slog.Any(f.Key, f.Value))
+	}
+	_ = b.handler.Handle(ctx, rec)
+}
+
+func slogLevel(level Level) slog.Level {
+	switch level {
+	case TraceLevel:
+		return slogTraceLevel
+	case DebugLevel:
+		return slog.LevelDebug
+	case InfoLevel:
+		return slog.LevelInfo
+	case WarnLevel:
+		return slog.LevelWarn
+	case FatalLevel:
+		return slogFatalLevel
+	case PanicLevel:
+		return slogPanicLevel
+	default:
+		return slog.LevelError
+	}
+}
+
+// levelFromSlog gives the level of the events logged through slog, which are never logged above error, so that
+// they never panic or exit.
+func levelFromSlog(level slog.Level) zerolog.Level {
+	switch {
+	case level < slog.LevelDebug:
+		return zerolog.TraceLevel
+	case level < slog.LevelInfo:
+		return zerolog.DebugLevel
+	case level < slog.LevelWarn:
+		return zerolog.InfoLevel
+	case level < slog.LevelError:
+		return zerolog.WarnLevel
+	default:
+		return zerolog.ErrorLevel
+	}
+}
+
+// slogHandler is the handler of log/slog logging using an instance.
+type slogHandler struct {
+	instance *Instance
+	// attrs is the attributes added using WithAttrs, along with the groups they were added in
+	attrs []groupedAttr
+	// group is the prefix of the keys of the attributes, from the groups opened using WithGroup
+	group string
+}
+
+type groupedAttr struct {
+	group string
+	attr  slog.Attr
+}
+
+// NewSlogHandler is used to create the handler of log/slog logging using the instance, or the default instance at the
+// time of logging if it is nil, so that the libraries logging through slog, for example using
+// slog.New(NewSlogHandler(nil)), log in the same way as the rest of the program, with the level, the params in the
+// context, the hooks and the sampling of the instance.
+//
+// The attributes in the groups are added with their keys prefixed by the names of the groups, separated by dots.
+// The levels of slog above error are logged as error, so that they never panic or exit.
+//
+// The instance must not write to the handler of the default slog logger, using NewSlogBackend, while the handler is
+// set as the default using slog.SetDefault, since the events would then be written back to the handler endlessly.
+func NewSlogHandler(i *Instance) slog.Handler {
+	return &slogHandler{instance: i}
+}
+
+// Enabled is used to check whether the events of the level are logged by the instance.
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.getInstance().enabled(levelFromSlog(level))
+}
+
+// Handle is used to log the record using the instance.
+func (h *slogHandler) Handle(ctx context.Context, rec slog.Record) error {
+	x := h.getInstance().newL(ctx, levelFromSlog(rec.Level))
+	if !x.enabled() {
+		x.dispose()
+		return nil
+	}
+	if rec.PC != 0 {
+		f, _ := runtime.CallersFrames([]uintptr{rec.PC}).Next()
+		x.caller = zerolog.CallerMarshalFunc(f.PC, f.File, f.Line)
+		x.pc = rec.PC
+	}
+	for _, a := range h.attrs {
+		addAttr(x, a.group, a.attr)
+	}
+	rec.Attrs(func(a slog.Attr) bool {
+		addAttr(x, h.group, a)
+		return true
+	})
+	x.Msg(rec.Message)
+	return nil
+}
+
+// WithAttrs is used to create the handler adding the attributes to all the events.
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	c := *h
+	c.attrs = make([]groupedAttr, len(h.attrs), len(h.attrs)+len(attrs))
+	copy(c.attrs, h.attrs)
+	for _, a := range attrs {
+		c.attrs = append(c.attrs, groupedAttr{group: h.group, attr: a})
+	}
+	return &c
+}
+
+// WithGroup is used to create the handler adding the attributes in the group.
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	c := *h
+	c.group = h.group + name + "."
+	return &c
+}
+
+func (h *slogHandler) getInstance() *Instance {
+	if h.instance == nil {
+		return Default()
+	}
+	return h.instance
+}
+
+func addAttr(x *l, group string, a slog.Attr) {
+	v := a.Value.Resolve()
+	if v.Kind() == slog.KindGroup {
+		attrs := v.Group()
+		// the groups without attributes are ignored, and the ones without a key are inlined
+		if len(attrs) == 0 {
+			return
+		}
+		if a.Key != "" {
+			group += a.Key + "."
+		}
+		for _, ga := range attrs {
+			addAttr(x, group, ga)
+		}
+		return
+	}
+	if a.Key == "" && v.Any() == nil {
+		// the empty attributes are ignored
+		return
+	}
+	key := group + a.Key
+	switch v.Kind() {
+	case slog.KindString:
+		x.Str(key, v.String())
+	case slog.KindInt64:
+		x.Int64(key, v.Int64())
+	case slog.KindUint64:
+		x.Uint64(key, v.Uint64())
+	case slog.KindFloat64:
+		x.Float64(key, v.Float64())
+	case slog.KindBool:
+		x.Bool(key, v.Bool())
+	default:
+		x.any(key, v.Any())
+	}
+}
@@ -0,0 +1,79 @@
+//go:build go1.21
+
+package log_test
+
+import (
+	"context"
+	stdErrors "errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/sinhashubham95/go-utils/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlogBackend(t *testing.T) {
+	var b syncBuffer
+	h := slog.NewJSONHandler(&b, &slog.HandlerOptions{AddSource: true, Level: slog.LevelDebug})
+	i := log.New(log.WithLevel(log.TraceLevel), log.WithBackend(log.NewSlogBackend(h)), log.WithParams("request"))
+	ctx := context.WithValue(context.Background(), "request", "naruto")
+
+	i.Trace(ctx).Msg("ignored by the handler")
+	i.Component("db").Warn(ctx).Str("query", "select").Err(stdErrors.New("failed")).Msg("slow query")
+
+	lines := b.lines(t)
+	assert.Len(t, lines, 1)
+	assert.Equal(t, "WARN", lines[0][slog.LevelKey])
+	assert.Equal(t, "slow query", lines[0][slog.MessageKey])
+	assert.Equal(t, "db", lines[0][log.ComponentLogParam])
+	assert.Equal(t, "naruto", lines[0]["request"])
+	assert.Equal(t, "select", lines[0]["query"])
+	assert.Equal(t, "failed", lines[0][zerolog.ErrorFieldName])
+	assert.Contains(t, lines[0][slog.SourceKey].(map[string]interface{})["file"], "slog_test.go")
+
+	assert.Panics(t, func() { log.NewSlogBackend(nil) })
+}
+
+func TestSlogHandler(t *testing.T) {
+	r := log.NewRecorder()
+	i := log.New(log.WithLevel(log.InfoLevel), log.WithBackend(r), log.WithParams("request"))
+	logger := slog.New(log.NewSlogHandler(i.Component("lib")))
+	ctx := context.WithValue(context.Background(), "request", "naruto")
+
+	assert.False(t, logger.Enabled(ctx, slog.LevelDebug))
+	assert.True(t, logger.Enabled(ctx, slog.LevelInfo))
+	logger.DebugContext(ctx, "ignored")
+	logger.With("user", 42).WithGroup("http").With("method", "GET").
+		ErrorContext(ctx, "request failed", "status", 500, slog.Group("timing", "took", time.Second),
+			"err", stdErrors.New("failed"), slog.Group("empty"))
+	logger.Log(ctx, slog.LevelError+8, "never panics")
+
+	records := r.Records()
+	assert.Len(t, records, 2)
+	assert.Equal(t, log.Level(log.ErrorLevel), records[0].Level)
+	assert.Equal(t, "request failed", records[0].Message)
+	assert.Contains(t, records[0].Caller, "slog_test.go")
+	assert.Equal(t, map[string]interface{}{
+		log.ComponentLogParam: "lib",
+		"request":             "naruto",
+		"user":                int64(42),
+		"http.method":         "GET",
+		"http.status":         int64(500),
+		"http.timing.took":    time.Second,
+		"http.err":            stdErrors.New("failed"),
+	}, records[0].FieldsMap())
+	assert.Equal(t, log.Level(log.ErrorLevel), records[1].Level)
+}
+
+func TestSlogHandlerDefault(t *testing.T) {
+	r := log.NewRecorder()
+	previous := log.Default()
+	log.SetDefault(log.New(log.WithBackend(r)))
+	defer log.SetDefault(previous)
+
+	slog.New(log.NewSlogHandler(nil)).Info("hello")
+	assert.Len(t, r.Records(), 1)
+	assert.Equal(t, "hello", r.Records()[0].Message)
+}
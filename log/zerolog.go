@@ -0,0 +1,47 @@
+package log
+
+import (
+	"io"
+
+	"github.com/rs/zerolog"
+)
+
+// zerologBackend is the backend writing the events in JSON using zerolog. The instances using it build the zerolog
+// events directly rather than the records, so that logging does not allocate.
+//
+// The levels of the instances decide which events are logged, so the events are created without a level for zerolog,
+// with the level added as a field, so that the global level of zerolog does not filter them, other than
+// zerolog.Disabled.
+type zerologBackend struct {
+	logger zerolog.Logger
+}
+
+// NewZerologBackend is used to create the backend writing the events in JSON to the writer using zerolog,
+// which is the backend of the instances by default.
+func NewZerologBackend(w io.Writer) Backend {
+	return &zerologBackend{logger: zerolog.New(w)}
+}
+
+// Write is used to write the record using zerolog.
+func (b *zerologBackend) Write(r *Record) {
+	e := b.event(r.Level.zeroLogLevel())
+	for _, f := range r.Fields {
+		switch v := f.Value.(type) {
+		case error:
+			e.AnErr(f.Key, v)
+		case []error:
+			e.Errs(f.Key, v)
+		default:
+			e.Interface(f.Key, v)
+		}
+	}
+	if r.Caller != "" {
+		e.Str(zerolog.CallerFieldName, r.Caller)
+	}
+	e.Time(zerolog.TimestampFieldName, r.Time).Msg(r.Message)
+}
+
+// event starts the zerolog event of the level.
+func (b *zerologBackend) event(level zerolog.Level) *zerolog.Event {
+	return b.logger.WithLevel(zerolog.NoLevel).Str(zerolog.LevelFieldName, zerolog.LevelFieldMarshalFunc(level))
+}
@@ -0,0 +1,144 @@
+package numbers
+
+import (
+	"fmt"
+	"math/bits"
+	"unsafe"
+)
+
+// PopCount returns the number of one bits ("population count") in the given number.
+// Negative numbers are counted using their two's complement representation.
+func PopCount[K IntegerNumber](a K) int {
+	return bits.OnesCount64(toBits(a))
+}
+
+// LeadingZeros returns the number of leading zero bits in the given number.
+// The result is the bit size of the type for 0.
+func LeadingZeros[K IntegerNumber](a K) int {
+	return bits.LeadingZeros64(toBits(a)) - (64 - bitSize[K]())
+}
+
+// TrailingZeros returns the number of trailing zero bits in the given number.
+// The result is the bit size of the type for 0.
+func TrailingZeros[K IntegerNumber](a K) int {
+	u := toBits(a)
+	if u == 0 {
+		return bitSize[K]()
+	}
+	return bits.TrailingZeros64(u)
+}
+
+// BitLength returns the minimum number of bits required to represent the given number.
+// The result is 0 for 0.
+func BitLength[K IntegerNumber](a K) int {
+	return bits.Len64(toBits(a))
+}
+
+// RotateLeft returns the value of the given number rotated left by k bits within the bit size of its type.
+// To rotate right by k bits, call RotateLeft(a, -k).
+func RotateLeft[K IntegerNumber](a K, k int) K {
+	n := bitSize[K]()
+	s := k % n
+	if s < 0 {
+		s += n
+	}
+	if s == 0 {
+		return a
+	}
+	u := toBits(a)
+	return K((u<<s | u>>(n-s)) & bitMask(n))
+}
+
+// RotateRight returns the value of the given number rotated right by k bits within the bit size of its type.
+func RotateRight[K IntegerNumber](a K, k int) K {
+	return RotateLeft(a, -k)
+}
+
+// ReverseBits returns the value of the given number with its bits in reversed order.
+func ReverseBits[K IntegerNumber](a K) K {
+	return K(bits.Reverse64(toBits(a)) >> (64 - bitSize[K]()))
+}
+
+// IsPowerOfTwo is used to check if the given number is a power of two.
+// Zero and negative numbers are never powers of two.
+func IsPowerOfTwo[K IntegerNumber](a K) bool {
+	return a > 0 && a&(a-1) == 0
+}
+
+// NextPowerOfTwo returns the smallest power of two greater than or equal to the given number.
+// The result is 1 for numbers less than or equal to 1, and 0 if the power of two overflows the type.
+func NextPowerOfTwo[K IntegerNumber](a K) K {
+	if a <= 1 {
+		return 1
+	}
+	l := bits.Len64(toBits(a - 1))
+	n := bitSize[K]()
+	if isSigned[K]() {
+		n -= 1
+	}
+	if l >= n {
+		return 0
+	}
+	return K(uint64(1) << l)
+}
+
+// ExtractBits returns the bit field of the given width starting at the given offset, counted from the least
+// significant bit.
+//
+// It panics if the field does not fit within the bit size of the type.
+func ExtractBits[K IntegerNumber](a K, offset, width int) K {
+	checkBitField[K](offset, width)
+	return K((toBits(a) >> offset) & bitMask(width))
+}
+
+// InsertBits returns the given number with the bit field of the given width starting at the given offset replaced
+// by the lowest bits of the value. The bits of the value beyond the width are ignored.
+//
+// It panics if the field does not fit within the bit size of the type.
+func InsertBits[K IntegerNumber](a, v K, offset, width int) K {
+	checkBitField[K](offset, width)
+	m := bitMask(width) << offset
+	return K((toBits(a) &^ m) | ((toBits(v) << offset) & m))
+}
+
+// ToGrayCode is used to convert the given number to its reflected binary Gray code.
+func ToGrayCode[K IntegerNumber](a K) K {
+	u := toBits(a)
+	return K(u ^ (u >> 1))
+}
+
+// FromGrayCode is used to convert the given reflected binary Gray code back to the number.
+func FromGrayCode[K IntegerNumber](a K) K {
+	u := toBits(a)
+	for s := 1; s < bitSize[K](); s <<= 1 {
+		u ^= u >> s
+	}
+	return K(u)
+}
+
+func bitSize[K Number]() int {
+	var a K
+	return int(unsafe.Sizeof(a)) * 8
+}
+
+func isSigned[K Number]() bool {
+	var a K
+	return a-1 < a
+}
+
+func bitMask(n int) uint64 {
+	if n == 0 {
+		return 0
+	}
+	return ^uint64(0) >> (64 - n)
+}
+
+func toBits[K IntegerNumber](a K) uint64 {
+	return uint64(a) & bitMask(bitSize[K]())
+}
+
+func checkBitField[K IntegerNumber](offset, width int) {
+	if offset < 0 || width < 0 || offset+width > bitSize[K]() {
+		panic(fmt.Sprintf("bit field at offset %d of width %d does not fit in %d bits", offset, width, bitSize[K]()))
+	}
+}
@@ -0,0 +1,109 @@
+package numbers_test
+
+import (
+	"testing"
+
+	"github.com/sinhashubham95/go-utils/numbers"
+	"github.com/stretchr/testify/assert"
+)
+
+type flags uint16
+
+func TestPopCount(t *testing.T) {
+	assert.Equal(t, 0, numbers.PopCount[uint8](0))
+	assert.Equal(t, 3, numbers.PopCount[uint8](0b10101))
+	assert.Equal(t, 8, numbers.PopCount[int8](-1))
+	assert.Equal(t, 64, numbers.PopCount[int64](-1))
+	assert.Equal(t, 2, numbers.PopCount[flags](0x8001))
+}
+
+func TestLeadingZeros(t *testing.T) {
+	assert.Equal(t, 8, numbers.LeadingZeros[uint8](0))
+	assert.Equal(t, 7, numbers.LeadingZeros[uint8](1))
+	assert.Equal(t, 0, numbers.LeadingZeros[int16](-1))
+	assert.Equal(t, 31, numbers.LeadingZeros[uint32](1))
+	assert.Equal(t, 64, numbers.LeadingZeros[uint64](0))
+}
+
+func TestTrailingZeros(t *testing.T) {
+	assert.Equal(t, 8, numbers.TrailingZeros[uint8](0))
+	assert.Equal(t, 3, numbers.TrailingZeros[uint8](8))
+	assert.Equal(t, 7, numbers.TrailingZeros[int8](-128))
+	assert.Equal(t, 32, numbers.TrailingZeros[int32](0))
+}
+
+func TestBitLength(t *testing.T) {
+	assert.Equal(t, 0, numbers.BitLength[uint8](0))
+	assert.Equal(t, 4, numbers.BitLength[uint8](8))
+	assert.Equal(t, 8, numbers.BitLength[int8](-1))
+}
+
+func TestRotate(t *testing.T) {
+	assert.Equal(t, uint8(0b00000011), numbers.RotateLeft[uint8](0b10000001, 1))
+	assert.Equal(t, uint8(0b11000000), numbers.RotateRight[uint8](0b10000001, 1))
+	assert.Equal(t, uint8(0b10000001), numbers.RotateLeft[uint8](0b10000001, 8))
+	assert.Equal(t, uint8(0b11000000), numbers.RotateLeft[uint8](0b10000001, -1))
+	assert.Equal(t, int8(-1), numbers.RotateLeft[int8](-1, 3))
+	assert.Equal(t, int16(-32768), numbers.RotateRight[int16](1, 1))
+	assert.Equal(t, uint64(1), numbers.RotateLeft[uint64](1<<63, 1))
+}
+
+func TestReverseBits(t *testing.T) {
+	assert.Equal(t, uint8(0b10000000), numbers.ReverseBits[uint8](1))
+	assert.Equal(t, uint16(0x8000), numbers.ReverseBits[uint16](1))
+	assert.Equal(t, int8(1), numbers.ReverseBits[int8](-128))
+	assert.Equal(t, uint32(0x0f000000), numbers.ReverseBits[uint32](0xf0))
+}
+
+func TestIsPowerOfTwo(t *testing.T) {
+	assert.False(t, numbers.IsPowerOfTwo(0))
+	assert.True(t, numbers.IsPowerOfTwo(1))
+	assert.True(t, numbers.IsPowerOfTwo[uint8](128))
+	assert.False(t, numbers.IsPowerOfTwo[int8](-128))
+	assert.False(t, numbers.IsPowerOfTwo(6))
+}
+
+func TestNextPowerOfTwo(t *testing.T) {
+	assert.Equal(t, 1, numbers.NextPowerOfTwo(-5))
+	assert.Equal(t, 1, numbers.NextPowerOfTwo(0))
+	assert.Equal(t, 1, numbers.NextPowerOfTwo(1))
+	assert.Equal(t, 2, numbers.NextPowerOfTwo(2))
+	assert.Equal(t, 8, numbers.NextPowerOfTwo(5))
+	assert.Equal(t, 8, numbers.NextPowerOfTwo(8))
+	assert.Equal(t, uint8(128), numbers.NextPowerOfTwo[uint8](100))
+	assert.Equal(t, uint8(0), numbers.NextPowerOfTwo[uint8](129))
+	assert.Equal(t, int8(64), numbers.NextPowerOfTwo[int8](63))
+	assert.Equal(t, int8(0), numbers.NextPowerOfTwo[int8](65))
+	assert.Equal(t, uint64(1<<63), numbers.NextPowerOfTwo[uint64](1<<62+1))
+}
+
+func TestExtractBits(t *testing.T) {
+	assert.Equal(t, uint16(0xb), numbers.ExtractBits[uint16](0xabcd, 8, 4))
+	assert.Equal(t, uint16(0xabcd), numbers.ExtractBits[uint16](0xabcd, 0, 16))
+	assert.Equal(t, uint16(0), numbers.ExtractBits[uint16](0xabcd, 4, 0))
+	assert.Equal(t, int8(3), numbers.ExtractBits[int8](-1, 6, 2))
+	assert.Panics(t, func() { numbers.ExtractBits[uint8](0, 4, 5) })
+	assert.Panics(t, func() { numbers.ExtractBits[uint8](0, -1, 2) })
+}
+
+func TestInsertBits(t *testing.T) {
+	assert.Equal(t, uint16(0xa5cd), numbers.InsertBits[uint16](0xabcd, 5, 8, 4))
+	assert.Equal(t, uint16(0xafcd), numbers.InsertBits[uint16](0xabcd, 0xff, 8, 4))
+	assert.Equal(t, int8(-128), numbers.InsertBits[int8](0, 1, 7, 1))
+	assert.Equal(t, flags(0x00f0), numbers.InsertBits[flags](0, 0xf, 4, 4))
+	assert.Panics(t, func() { numbers.InsertBits[uint8](0, 0, 8, 1) })
+}
+
+func TestGrayCode(t *testing.T) {
+	expected := []uint8{0, 1, 3, 2, 6, 7, 5, 4}
+	for i, g := range expected {
+		assert.Equal(t, g, numbers.ToGrayCode(uint8(i)))
+		assert.Equal(t, uint8(i), numbers.FromGrayCode(g))
+	}
+	for _, v := range []int16{-32768, -1, 0, 1, 12345, 32767} {
+		assert.Equal(t, v, numbers.FromGrayCode(numbers.ToGrayCode(v)))
+	}
+	for _, v := range []uint64{0, 1, 1 << 63, 0xdeadbeefcafebabe} {
+		assert.Equal(t, v, numbers.FromGrayCode(numbers.ToGrayCode(v)))
+	}
+}
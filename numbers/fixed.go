@@ -0,0 +1,422 @@
+package numbers
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// maximum number of fractional bits supported by the fixed point numbers
+const maxFractionalBits = 60
+
+// FractionalBits is the generic type for the precision of the fixed point numbers.
+// It reports the number of bits of the fixed point number used to represent the fraction, between 0 and 60.
+type FractionalBits interface {
+	FractionalBits() uint
+}
+
+// Q8 is the precision with 8 fractional bits.
+type Q8 struct{}
+
+// FractionalBits returns the number of fractional bits.
+func (Q8) FractionalBits() uint { return 8 }
+
+// Q16 is the precision with 16 fractional bits.
+type Q16 struct{}
+
+// FractionalBits returns the number of fractional bits.
+func (Q16) FractionalBits() uint { return 16 }
+
+// Q24 is the precision with 24 fractional bits.
+type Q24 struct{}
+
+// FractionalBits returns the number of fractional bits.
+func (Q24) FractionalBits() uint { return 24 }
+
+// Q32 is the precision with 32 fractional bits.
+type Q32 struct{}
+
+// FractionalBits returns the number of fractional bits.
+func (Q32) FractionalBits() uint { return 32 }
+
+// Fixed is a signed fixed point number backed by an int64, with the number of fractional bits given by Q.
+// The raw value is the number multiplied by 2^Q.
+//
+// All the arithmetic is performed on integers, so the results are identical on every platform.
+// Like the integer types, the arithmetic wraps around on overflow.
+type Fixed[Q FractionalBits] int64
+
+// FixedFromRaw is used to create the fixed point number from its raw representation.
+func FixedFromRaw[Q FractionalBits](raw int64) Fixed[Q] {
+	return Fixed[Q](raw)
+}
+
+// FixedFromInt is used to convert the integer to a fixed point number.
+func FixedFromInt[Q FractionalBits, K IntegerNumber](a K) Fixed[Q] {
+	return Fixed[Q](int64(a) << fractionalBits[Q]())
+}
+
+// FixedFromFloat is used to convert the floating number to the nearest fixed point number.
+// Halfway cases are rounded away from zero.
+//
+// The numbers out of the range of the fixed point numbers, including the infinities, saturate to the largest or the
+// smallest fixed point number, and NaN is converted to 0, so that the result is the same on every platform.
+func FixedFromFloat[Q FractionalBits, K FloatingNumber](a K) Fixed[Q] {
+	v := math.Round(math.Ldexp(float64(a), int(fractionalBits[Q]())))
+	switch {
+	case math.IsNaN(v):
+		return 0
+	case v >= 1<<63:
+		return Fixed[Q](MaxInt64)
+	case v < -1<<63:
+		return Fixed[Q](MinInt64)
+	}
+	return Fixed[Q](int64(v))
+}
+
+// StringToFixed is used to convert the decimal string to the nearest fixed point number.
+// Halfway cases are rounded away from zero.
+//
+// The string is of the form [+-]digits[.digits], with the integer part parsed using StringToNumber.
+func StringToFixed[Q FractionalBits](a string) (Fixed[Q], error) {
+	s := a
+	negative := false
+	if s != "" && (s[0] == '+' || s[0] == '-') {
+		negative = s[0] == '-'
+		s = s[1:]
+	}
+	i, f, _ := strings.Cut(s, ".")
+	if (i == "" && f == "") || !isDigits(i) || !isDigits(f) {
+		return 0, numError("StringToFixed", a, strconv.ErrSyntax)
+	}
+	var ip uint64
+	if i != "" {
+		v, err := StringToNumber[uint64](i)
+		if err != nil {
+			return 0, numError("StringToFixed", a, strconv.ErrRange)
+		}
+		ip = v
+	}
+	q := fractionalBits[Q]()
+	if ip > uint64(MaxInt64)>>q+1 {
+		return 0, numError("StringToFixed", a, strconv.ErrRange)
+	}
+	raw := ip << q
+	if f != "" {
+		raw += decimalFractionToBits(f, q)
+	}
+	if negative {
+		if raw > uint64(MaxInt64)+1 {
+			return 0, numError("StringToFixed", a, strconv.ErrRange)
+		}
+		return Fixed[Q](-raw), nil
+	}
+	if raw > uint64(MaxInt64) {
+		return 0, numError("StringToFixed", a, strconv.ErrRange)
+	}
+	return Fixed[Q](raw), nil
+}
+
+// Raw returns the raw representation of the fixed point number, i.e. the number multiplied by 2^Q.
+func (f Fixed[Q]) Raw() int64 {
+	return int64(f)
+}
+
+// Int64 returns the integer part of the fixed point number, truncated towards zero.
+func (f Fixed[Q]) Int64() int64 {
+	return int64(f.Trunc()) >> fractionalBits[Q]()
+}
+
+// Float64 returns the nearest floating number to the fixed point number.
+func (f Fixed[Q]) Float64() float64 {
+	return math.Ldexp(float64(f), -int(fractionalBits[Q]()))
+}
+
+// Add returns the sum f+o.
+func (f Fixed[Q]) Add(o Fixed[Q]) Fixed[Q] {
+	return f + o
+}
+
+// Sub returns the difference f-o.
+func (f Fixed[Q]) Sub(o Fixed[Q]) Fixed[Q] {
+	return f - o
+}
+
+// Mul returns the product f*o, rounded to the nearest fixed point number.
+// Halfway cases are rounded away from zero.
+func (f Fixed[Q]) Mul(o Fixed[Q]) Fixed[Q] {
+	q := fractionalBits[Q]()
+	a, an := absBits(int64(f))
+	b, bn := absBits(int64(o))
+	hi, lo := bits.Mul64(a, b)
+	if q > 0 {
+		var carry uint64
+		lo, carry = bits.Add64(lo, 1<<(q-1), 0)
+		hi += carry
+		lo = hi<<(64-q) | lo>>q
+	}
+	return Fixed[Q](withSign(lo, an != bn))
+}
+
+// Div returns the quotient f/o, rounded to the nearest fixed point number.
+// Halfway cases are rounded away from zero.
+//
+// It panics if o is zero.
+func (f Fixed[Q]) Div(o Fixed[Q]) Fixed[Q] {
+	if o == 0 {
+		panic("fixed point division by zero")
+	}
+	q := fractionalBits[Q]()
+	a, an := absBits(int64(f))
+	b, bn := absBits(int64(o))
+	var hi, lo uint64
+	if q > 0 {
+		hi, lo = a>>(64-q), a<<q
+	} else {
+		lo = a
+	}
+	quo, rem := bits.Div64(hi%b, lo, b)
+	if rem >= b-rem {
+		quo += 1
+	}
+	return Fixed[Q](withSign(quo, an != bn))
+}
+
+// Neg returns the negation -f.
+func (f Fixed[Q]) Neg() Fixed[Q] {
+	return -f
+}
+
+// Abs returns the absolute value of f.
+func (f Fixed[Q]) Abs() Fixed[Q] {
+	if f < 0 {
+		return -f
+	}
+	return f
+}
+
+// Sign returns -1 if f < 0, 0 if f == 0 and +1 if f > 0.
+func (f Fixed[Q]) Sign() int {
+	return Compare(f, 0)
+}
+
+// Compare is used to compare the fixed point numbers.
+// The result will be 0 if f==o, -1 if f < o, and +1 if f > o.
+func (f Fixed[Q]) Compare(o Fixed[Q]) int {
+	return Compare(f, o)
+}
+
+// Floor returns the greatest integer value less than or equal to f.
+func (f Fixed[Q]) Floor() Fixed[Q] {
+	return f &^ fractionMask[Q]()
+}
+
+// Ceil returns the least integer value greater than or equal to f.
+func (f Fixed[Q]) Ceil() Fixed[Q] {
+	return -(-f).Floor()
+}
+
+// Trunc returns the integer value of f truncated towards zero.
+func (f Fixed[Q]) Trunc() Fixed[Q] {
+	if f < 0 {
+		return f.Ceil()
+	}
+	return f.Floor()
+}
+
+// Round returns the nearest integer value to f, rounding half away from zero.
+func (f Fixed[Q]) Round() Fixed[Q] {
+	q := fractionalBits[Q]()
+	if q == 0 {
+		return f
+	}
+	half := Fixed[Q](1) << (q - 1)
+	if f < 0 {
+		return -(-f + half).Floor()
+	}
+	return (f + half).Floor()
+}
+
+// RoundToEven returns the nearest integer value to f, rounding ties to even.
+func (f Fixed[Q]) RoundToEven() Fixed[Q] {
+	q := fractionalBits[Q]()
+	if q == 0 {
+		return f
+	}
+	half := Fixed[Q](1) << (q - 1)
+	floor := f.Floor()
+	switch Compare(f-floor, half) {
+	case -1:
+		return floor
+	case 1:
+		return floor + Fixed[Q](1)<<q
+	}
+	if (floor>>q)&1 == 0 {
+		return floor
+	}
+	return floor + Fixed[Q](1)<<q
+}
+
+// Format is used to convert the fixed point number to a decimal string with exactly the given number of digits
+// after the decimal point. Halfway cases are rounded away from zero.
+func (f Fixed[Q]) Format(precision int) string {
+	if precision < 0 {
+		precision = 0
+	}
+	return formatFixed(int64(f), fractionalBits[Q](), precision, false)
+}
+
+// String is used to convert the fixed point number to a decimal string, with just enough digits after the
+// decimal point for StringToFixed to convert it back to the same number.
+func (f Fixed[Q]) String() string {
+	q := fractionalBits[Q]()
+	return formatFixed(int64(f), q, roundTripDigits(q), true)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (f Fixed[Q]) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (f *Fixed[Q]) UnmarshalText(text []byte) error {
+	v, err := StringToFixed[Q](string(text))
+	if err != nil {
+		return err
+	}
+	*f = v
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// The fixed point number is encoded as a JSON number holding its exact decimal representation.
+func (f Fixed[Q]) MarshalJSON() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Both JSON numbers and JSON strings holding a decimal number are accepted.
+func (f *Fixed[Q]) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	if len(s) > 1 && s[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	}
+	return f.UnmarshalText([]byte(s))
+}
+
+func fractionalBits[Q FractionalBits]() uint {
+	var q Q
+	b := q.FractionalBits()
+	if b > maxFractionalBits {
+		panic(fmt.Sprintf("fixed point numbers support at most %d fractional bits, got %d", maxFractionalBits, b))
+	}
+	return b
+}
+
+func fractionMask[Q FractionalBits]() Fixed[Q] {
+	return Fixed[Q](1)<<fractionalBits[Q]() - 1
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i += 1 {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func absBits(a int64) (uint64, bool) {
+	if a < 0 {
+		return uint64(^a) + 1, true
+	}
+	return uint64(a), false
+}
+
+func withSign(a uint64, negative bool) int64 {
+	if negative {
+		return -int64(a)
+	}
+	return int64(a)
+}
+
+// roundTripDigits returns the number of decimal digits d such that 10^d >= 2^(q+1),
+// which is enough for the decimal representation to identify the fixed point number.
+func roundTripDigits(q uint) int {
+	return int(math.Ceil(float64(q+1) * math.Log10(2)))
+}
+
+// decimalFractionToBits converts the decimal digits after the point to the nearest fraction with q bits.
+func decimalFractionToBits(f string, q uint) uint64 {
+	// the bits of the fraction are computed exactly, by doubling the decimal digits and taking the carry out as the
+	// next bit, so that it is rounded only once, by the bit after the last one kept
+	f = strings.TrimRight(f, "0")
+	digits := []byte(f)
+	for i := range digits {
+		digits[i] -= '0'
+	}
+	var r uint64
+	for i := uint(0); i <= q; i += 1 {
+		var carry byte
+		for j := len(digits) - 1; j >= 0; j -= 1 {
+			d := digits[j]*2 + carry
+			digits[j], carry = d%10, d/10
+		}
+		if i < q {
+			r = r<<1 | uint64(carry)
+		} else if carry == 1 {
+			// the rest of the fraction is at least half
+			r += 1
+		}
+	}
+	return r
+}
+
+func formatFixed(raw int64, q uint, precision int, trim bool) string {
+	a, negative := absBits(raw)
+	ip := a >> q
+	fp := a & (uint64(1)<<q - 1)
+	digits := make([]byte, precision)
+	for i := range digits {
+		fp *= 10
+		digits[i] = byte('0' + fp>>q)
+		fp &= uint64(1)<<q - 1
+	}
+	// round half away from zero based on the remaining fraction
+	if q > 0 && fp >= uint64(1)<<(q-1) {
+		i := precision - 1
+		for ; i >= 0; i -= 1 {
+			if digits[i] < '9' {
+				digits[i] += 1
+				break
+			}
+			digits[i] = '0'
+		}
+		if i < 0 {
+			ip += 1
+		}
+	}
+	if trim {
+		for len(digits) > 0 && digits[len(digits)-1] == '0' {
+			digits = digits[:len(digits)-1]
+		}
+	}
+	var b strings.Builder
+	if negative && (ip != 0 || len(strings.Trim(string(digits), "0")) > 0) {
+		b.WriteByte('-')
+	}
+	b.WriteString(strconv.FormatUint(ip, 10))
+	if len(digits) > 0 {
+		b.WriteByte('.')
+		b.Write(digits)
+	}
+	return b.String()
+}
@@ -0,0 +1,203 @@
+package numbers_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/sinhashubham95/go-utils/numbers"
+	"github.com/stretchr/testify/assert"
+)
+
+type q60 struct{}
+
+func (q60) FractionalBits() uint { return 60 }
+
+type q61 struct{}
+
+func (q61) FractionalBits() uint { return 61 }
+
+type q0 struct{}
+
+func (q0) FractionalBits() uint { return 0 }
+
+func TestFixedConversions(t *testing.T) {
+	assert.Equal(t, int64(3<<16), numbers.FixedFromInt[numbers.Q16](3).Raw())
+	assert.Equal(t, int64(-3), numbers.FixedFromInt[numbers.Q16](-3).Int64())
+	assert.Equal(t, int64(1<<15), numbers.FixedFromFloat[numbers.Q16](0.5).Raw())
+	assert.Equal(t, 2.25, numbers.FixedFromFloat[numbers.Q16](2.25).Float64())
+	assert.Equal(t, -2.25, numbers.FixedFromFloat[numbers.Q8, float32](-2.25).Float64())
+	assert.Equal(t, int64(-2), numbers.FixedFromFloat[numbers.Q16](-2.75).Int64())
+	assert.Equal(t, int64(42), numbers.FixedFromRaw[numbers.Q8](42).Raw())
+	assert.Panics(t, func() { numbers.FixedFromInt[q61](1) })
+}
+
+func TestFixedFromFloatSaturates(t *testing.T) {
+	assert.Equal(t, int64(0), numbers.FixedFromFloat[numbers.Q16](math.NaN()).Raw())
+	assert.Equal(t, numbers.MaxInt64, numbers.FixedFromFloat[numbers.Q16](math.Inf(1)).Raw())
+	assert.Equal(t, numbers.MinInt64, numbers.FixedFromFloat[numbers.Q16](math.Inf(-1)).Raw())
+	assert.Equal(t, numbers.MaxInt64, numbers.FixedFromFloat[numbers.Q16](1e15).Raw())
+	assert.Equal(t, numbers.MinInt64, numbers.FixedFromFloat[numbers.Q16](-1e15).Raw())
+	assert.Equal(t, numbers.MinInt64, numbers.FixedFromFloat[q0](-9223372036854775808.0).Raw())
+	assert.Equal(t, numbers.MaxInt64, numbers.FixedFromFloat[q0](9223372036854775808.0).Raw())
+}
+
+func TestFixedArithmetic(t *testing.T) {
+	a := numbers.FixedFromFloat[numbers.Q16](1.5)
+	b := numbers.FixedFromFloat[numbers.Q16](-0.25)
+	assert.Equal(t, 1.25, a.Add(b).Float64())
+	assert.Equal(t, 1.75, a.Sub(b).Float64())
+	assert.Equal(t, -0.375, a.Mul(b).Float64())
+	assert.Equal(t, -6.0, a.Div(b).Float64())
+	assert.Equal(t, 0.25, b.Neg().Float64())
+	assert.Equal(t, 0.25, b.Abs().Float64())
+	assert.Equal(t, 1.5, a.Abs().Float64())
+	assert.Equal(t, 1, a.Sign())
+	assert.Equal(t, -1, b.Sign())
+	assert.Equal(t, 0, numbers.Fixed[numbers.Q16](0).Sign())
+	assert.Equal(t, 1, a.Compare(b))
+	assert.Equal(t, -1, b.Compare(a))
+	assert.Equal(t, 0, a.Compare(a))
+	assert.Equal(t, 1, numbers.Compare(a, b))
+	assert.Panics(t, func() { a.Div(0) })
+
+	// rounding of the least significant bit
+	ulp := numbers.FixedFromRaw[numbers.Q8](1)
+	half := numbers.FixedFromFloat[numbers.Q8](0.5)
+	assert.Equal(t, int64(1), ulp.Mul(half).Raw())
+	assert.Equal(t, int64(-1), ulp.Neg().Mul(half).Raw())
+	assert.Equal(t, int64(1), ulp.Div(numbers.FixedFromInt[numbers.Q8](2)).Raw())
+	assert.Equal(t, int64(0), ulp.Div(numbers.FixedFromInt[numbers.Q8](3)).Raw())
+
+	// integers without fractional bits
+	assert.Equal(t, int64(6), numbers.FixedFromInt[q0](2).Mul(numbers.FixedFromInt[q0](3)).Int64())
+	assert.Equal(t, int64(4), numbers.FixedFromInt[q0](7).Div(numbers.FixedFromInt[q0](2)).Int64())
+
+	// one third computed repeatedly is bit identical
+	third := numbers.FixedFromInt[numbers.Q32](1).Div(numbers.FixedFromInt[numbers.Q32](3))
+	assert.Equal(t, int64(1431655765), third.Raw())
+	assert.Equal(t, int64(4294967295), third.Mul(numbers.FixedFromInt[numbers.Q32](3)).Raw())
+}
+
+func TestFixedRounding(t *testing.T) {
+	for _, c := range []struct {
+		v, floor, ceil, trunc, round, even float64
+	}{
+		{2.5, 2, 3, 2, 3, 2},
+		{3.5, 3, 4, 3, 4, 4},
+		{-2.5, -3, -2, -2, -3, -2},
+		{-3.5, -4, -3, -3, -4, -4},
+		{2.25, 2, 3, 2, 2, 2},
+		{-2.75, -3, -2, -2, -3, -3},
+		{4, 4, 4, 4, 4, 4},
+	} {
+		f := numbers.FixedFromFloat[numbers.Q16](c.v)
+		assert.Equal(t, c.floor, f.Floor().Float64(), "floor %v", c.v)
+		assert.Equal(t, c.ceil, f.Ceil().Float64(), "ceil %v", c.v)
+		assert.Equal(t, c.trunc, f.Trunc().Float64(), "trunc %v", c.v)
+		assert.Equal(t, c.round, f.Round().Float64(), "round %v", c.v)
+		assert.Equal(t, c.even, f.RoundToEven().Float64(), "round to even %v", c.v)
+	}
+	assert.Equal(t, int64(7), numbers.FixedFromInt[q0](7).Round().Int64())
+	assert.Equal(t, int64(7), numbers.FixedFromInt[q0](7).RoundToEven().Int64())
+}
+
+func TestStringToFixed(t *testing.T) {
+	f, err := numbers.StringToFixed[numbers.Q16]("1.5")
+	assert.NoError(t, err)
+	assert.Equal(t, 1.5, f.Float64())
+	f, err = numbers.StringToFixed[numbers.Q16]("-0.25")
+	assert.NoError(t, err)
+	assert.Equal(t, -0.25, f.Float64())
+	f, err = numbers.StringToFixed[numbers.Q16]("+.5")
+	assert.NoError(t, err)
+	assert.Equal(t, 0.5, f.Float64())
+	f, err = numbers.StringToFixed[numbers.Q16]("7.")
+	assert.NoError(t, err)
+	assert.Equal(t, 7.0, f.Float64())
+	f8, err := numbers.StringToFixed[numbers.Q8]("0.99999")
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, f8.Float64())
+	f8, err = numbers.StringToFixed[numbers.Q8]("-128")
+	assert.NoError(t, err)
+	assert.Equal(t, -128.0, f8.Float64())
+	// the fraction is rounded once, so the digits just below the half do not round up
+	f8, err = numbers.StringToFixed[numbers.Q8]("0.0019531")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), f8.Raw())
+	f8, err = numbers.StringToFixed[numbers.Q8]("0.001953125")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), f8.Raw())
+	f8, err = numbers.StringToFixed[numbers.Q8]("0.00195312499999999999999999999")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), f8.Raw())
+	f0, err := numbers.StringToFixed[q0]("-9223372036854775808")
+	assert.NoError(t, err)
+	assert.Equal(t, numbers.MinInt64, f0.Raw())
+
+	for _, s := range []string{"", "-", ".", "1.2.3", "abc", "1e5", " 1"} {
+		_, err = numbers.StringToFixed[numbers.Q16](s)
+		assert.Error(t, err, s)
+	}
+	for _, s := range []string{"140737488355328", "-140737488355329", "99999999999999999999999"} {
+		_, err = numbers.StringToFixed[numbers.Q16](s)
+		assert.Error(t, err, s)
+	}
+	_, err = numbers.StringToFixed[q0]("9223372036854775808")
+	assert.Error(t, err)
+}
+
+func TestFixedString(t *testing.T) {
+	assert.Equal(t, "1.5", numbers.FixedFromFloat[numbers.Q16](1.5).String())
+	assert.Equal(t, "-0.25", numbers.FixedFromFloat[numbers.Q16](-0.25).String())
+	assert.Equal(t, "0", numbers.Fixed[numbers.Q16](0).String())
+	assert.Equal(t, "42", numbers.FixedFromInt[q0](42).String())
+	assert.Equal(t, "0.004", numbers.FixedFromRaw[numbers.Q8](1).String())
+	assert.Equal(t, "1.50", numbers.FixedFromFloat[numbers.Q16](1.5).Format(2))
+	assert.Equal(t, "2", numbers.FixedFromFloat[numbers.Q16](1.5).Format(-1))
+	assert.Equal(t, "-1.00", numbers.FixedFromFloat[numbers.Q16](-0.999).Format(2))
+	assert.Equal(t, "0.00", numbers.FixedFromFloat[numbers.Q16](-0.001).Format(2))
+	assert.Equal(t, "0.333", numbers.FixedFromInt[numbers.Q32](1).Div(numbers.FixedFromInt[numbers.Q32](3)).Format(3))
+
+	// every fixed point number survives the round trip through its string
+	for _, raw := range []int64{0, 1, -1, 12345, -98765, numbers.MaxInt64, numbers.MinInt64, 0x5555555555555} {
+		f8, err := numbers.StringToFixed[numbers.Q8](numbers.FixedFromRaw[numbers.Q8](raw).String())
+		assert.NoError(t, err)
+		assert.Equal(t, raw, f8.Raw())
+		f32, err := numbers.StringToFixed[numbers.Q32](numbers.FixedFromRaw[numbers.Q32](raw).String())
+		assert.NoError(t, err)
+		assert.Equal(t, raw, f32.Raw())
+		f60, err := numbers.StringToFixed[q60](numbers.FixedFromRaw[q60](raw).String())
+		assert.NoError(t, err)
+		assert.Equal(t, raw, f60.Raw())
+	}
+	assert.Equal(t, math.Pi, numbers.FixedFromFloat[q60](math.Pi).Float64())
+}
+
+func TestFixedMarshal(t *testing.T) {
+	type payload struct {
+		Price numbers.Fixed[numbers.Q16]  `json:"price"`
+		Ratio *numbers.Fixed[numbers.Q16] `json:"ratio"`
+	}
+	p := payload{Price: numbers.FixedFromFloat[numbers.Q16](12.75)}
+	b, err := json.Marshal(p)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"price":12.75,"ratio":null}`, string(b))
+
+	var d payload
+	assert.NoError(t, json.Unmarshal([]byte(`{"price":"-3.5","ratio":0.125}`), &d))
+	assert.Equal(t, -3.5, d.Price.Float64())
+	assert.Equal(t, 0.125, d.Ratio.Float64())
+	assert.NoError(t, json.Unmarshal([]byte(`{"price":null}`), &d))
+	assert.Equal(t, -3.5, d.Price.Float64())
+	assert.Error(t, json.Unmarshal([]byte(`{"price":"x"}`), &d))
+	assert.Error(t, json.Unmarshal([]byte(`{"price":1e3}`), &d))
+
+	text, err := p.Price.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "12.75", string(text))
+	var f numbers.Fixed[numbers.Q16]
+	assert.NoError(t, f.UnmarshalText([]byte("0.5")))
+	assert.Equal(t, 0.5, f.Float64())
+	assert.Error(t, f.UnmarshalText([]byte("half")))
+}
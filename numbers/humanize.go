@@ -0,0 +1,418 @@
+package numbers
+
+import (
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// units used for the human-readable formats
+var (
+	siByteUnits   = []string{"B", "kB", "MB", "GB", "TB", "PB", "EB"}
+	iecByteUnits  = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+	compactUnits  = []string{"", "k", "M", "B", "T"}
+	byteUnitSizes = map[string]uint64{
+		"":    1,
+		"b":   1,
+		"k":   1e3,
+		"kb":  1e3,
+		"m":   1e6,
+		"mb":  1e6,
+		"g":   1e9,
+		"gb":  1e9,
+		"t":   1e12,
+		"tb":  1e12,
+		"p":   1e15,
+		"pb":  1e15,
+		"e":   1e18,
+		"eb":  1e18,
+		"ki":  1 << 10,
+		"kib": 1 << 10,
+		"mi":  1 << 20,
+		"mib": 1 << 20,
+		"gi":  1 << 30,
+		"gib": 1 << 30,
+		"ti":  1 << 40,
+		"tib": 1 << 40,
+		"pi":  1 << 50,
+		"pib": 1 << 50,
+		"ei":  1 << 60,
+		"eib": 1 << 60,
+	}
+	compactUnitSizes = map[string]float64{
+		"":  1,
+		"k": 1e3,
+		"K": 1e3,
+		"M": 1e6,
+		"B": 1e9,
+		"T": 1e12,
+	}
+	durationUnits = []struct {
+		name string
+		size time.Duration
+	}{
+		{"d", 24 * time.Hour},
+		{"h", time.Hour},
+		{"m", time.Minute},
+		{"s", time.Second},
+		{"ms", time.Millisecond},
+		{"µs", time.Microsecond},
+		{"ns", time.Nanosecond},
+	}
+	durationUnitSizes = map[string]time.Duration{
+		"ns": time.Nanosecond,
+		"us": time.Microsecond,
+		"µs": time.Microsecond, // U+00B5 = micro symbol
+		"μs": time.Microsecond, // U+03BC = Greek letter mu
+		"ms": time.Millisecond,
+		"s":  time.Second,
+		"m":  time.Minute,
+		"h":  time.Hour,
+		"d":  24 * time.Hour,
+		"w":  7 * 24 * time.Hour,
+	}
+)
+
+// FormatThousands is used to convert the given integer number to string with the digits grouped in thousands
+// using the given separator, for example 1234567 is formatted as "1,234,567" with the separator ",".
+func FormatThousands[K IntegerNumber](a K, separator string) string {
+	var s string
+	if isSigned[K]() {
+		s = strconv.FormatInt(int64(a), 10)
+	} else {
+		s = strconv.FormatUint(uint64(a), 10)
+	}
+	sign := ""
+	if s[0] == '-' {
+		sign, s = "-", s[1:]
+	}
+	return sign + groupThousands(s, separator)
+}
+
+// ParseThousands is used to convert the string with the digits grouped in thousands using the given separator
+// back to the integer number. The first group has between 1 and 3 digits, and every other group exactly 3.
+func ParseThousands[K IntegerNumber](a, separator string) (K, error) {
+	s := a
+	if s != "" && (s[0] == '+' || s[0] == '-') {
+		s = s[1:]
+	}
+	if separator != "" {
+		groups := strings.Split(s, separator)
+		for i, g := range groups {
+			if g == "" || len(g) > 3 || (i > 0 && len(g) != 3) {
+				return 0, numError("ParseThousands", a, strconv.ErrSyntax)
+			}
+		}
+		s = strings.Join(groups, "")
+	}
+	if a != "" && a[0] == '-' {
+		s = "-" + s
+	}
+	r, err := parseDecimal[K](s)
+	if err != nil {
+		return 0, numError("ParseThousands", a, err)
+	}
+	return r, nil
+}
+
+// FormatBytesSI is used to convert the given number of bytes to a human-readable string in SI units,
+// which are powers of 1000, for example "3.2 MB".
+func FormatBytesSI[K IntegerNumber](a K) string {
+	return formatWithUnits(integerToFloat(a), 1000, siByteUnits, " ")
+}
+
+// FormatBytesIEC is used to convert the given number of bytes to a human-readable string in IEC units,
+// which are powers of 1024, for example "1.5 KiB".
+func FormatBytesIEC[K IntegerNumber](a K) string {
+	return formatWithUnits(integerToFloat(a), 1024, iecByteUnits, " ")
+}
+
+// ParseBytes is used to convert the human-readable byte size to the number of bytes.
+//
+// Both SI units (kB, MB, GB, TB, PB, EB) and IEC units (KiB, MiB, GiB, TiB, PiB, EiB) are accepted, with or
+// without the B suffix, in any case and with optional spaces in between, for example "10MiB", "1.5 GB" or "512".
+func ParseBytes(a string) (int64, error) {
+	n, u := splitNumberAndUnit(a)
+	size, ok := byteUnitSizes[strings.ToLower(u)]
+	if n == "" || !ok {
+		return 0, numError("ParseBytes", a, strconv.ErrSyntax)
+	}
+	if i, err := StringToNumber[int64](n); err == nil {
+		if i > MaxInt64/int64(size) || i < MinInt64/int64(size) {
+			return 0, numError("ParseBytes", a, strconv.ErrRange)
+		}
+		return i * int64(size), nil
+	}
+	f, err := StringToNumber[float64](n)
+	if err != nil {
+		return 0, numError("ParseBytes", a, err)
+	}
+	f = math.Round(f * float64(size))
+	if f >= -math.MinInt64 || f < math.MinInt64 {
+		return 0, numError("ParseBytes", a, strconv.ErrRange)
+	}
+	return int64(f), nil
+}
+
+// FormatDuration is used to convert the given duration to a human-readable string, listing every non-zero unit
+// from days down to nanoseconds, for example "1d 2h 30m" or "1s 500ms".
+func FormatDuration(d time.Duration) string {
+	if d == 0 {
+		return "0s"
+	}
+	sign := ""
+	u := uint64(d)
+	if d < 0 {
+		sign, u = "-", -u
+	}
+	parts := make([]string, 0, len(durationUnits))
+	for _, unit := range durationUnits {
+		if v := u / uint64(unit.size); v > 0 {
+			parts = append(parts, strconv.FormatUint(v, 10)+unit.name)
+			u -= v * uint64(unit.size)
+		}
+	}
+	return sign + strings.Join(parts, " ")
+}
+
+// ParseDuration is used to convert the human-readable duration to the duration.
+//
+// It accepts everything that time.ParseDuration does, along with days ("d") and weeks ("w") and spaces
+// between the components, for example "1w 2d", "1d 2h 30m" or "1.5h".
+func ParseDuration(a string) (time.Duration, error) {
+	s := strings.Join(strings.Fields(a), "")
+	negative := false
+	if s != "" && (s[0] == '+' || s[0] == '-') {
+		negative = s[0] == '-'
+		s = s[1:]
+	}
+	if s == "0" {
+		return 0, nil
+	}
+	if s == "" {
+		return 0, numError("ParseDuration", a, strconv.ErrSyntax)
+	}
+	// the nanoseconds are accumulated as integers, so that the long durations keep their precision
+	var d uint64
+	for s != "" {
+		i := strings.IndexFunc(s, func(r rune) bool { return r != '.' && (r < '0' || r > '9') })
+		if i <= 0 {
+			return 0, numError("ParseDuration", a, strconv.ErrSyntax)
+		}
+		n := s[:i]
+		s = s[i:]
+		j := strings.IndexFunc(s, func(r rune) bool { return r == '.' || (r >= '0' && r <= '9') })
+		if j < 0 {
+			j = len(s)
+		}
+		size, ok := durationUnitSizes[s[:j]]
+		if !ok {
+			return 0, numError("ParseDuration", a, strconv.ErrSyntax)
+		}
+		s = s[j:]
+		v, err := durationComponent(n, uint64(size))
+		if err != nil {
+			return 0, numError("ParseDuration", a, err)
+		}
+		var carry uint64
+		if d, carry = bits.Add64(d, v, 0); carry != 0 {
+			return 0, numError("ParseDuration", a, strconv.ErrRange)
+		}
+	}
+	if negative {
+		if d > 1<<63 {
+			return 0, numError("ParseDuration", a, strconv.ErrRange)
+		}
+		return time.Duration(-d), nil
+	}
+	if d > math.MaxInt64 {
+		return 0, numError("ParseDuration", a, strconv.ErrRange)
+	}
+	return time.Duration(d), nil
+}
+
+// durationComponent returns the nanoseconds of the decimal number n of the unit of the given size, rounded to the
+// nearest nanosecond.
+func durationComponent(n string, size uint64) (uint64, error) {
+	ip, fp, _ := strings.Cut(n, ".")
+	if (ip == "" && fp == "") || strings.Contains(fp, ".") {
+		return 0, strconv.ErrSyntax
+	}
+	var r uint64
+	if ip != "" {
+		v, err := strconv.ParseUint(ip, 10, 64)
+		if err != nil {
+			return 0, strconv.ErrRange
+		}
+		hi, lo := bits.Mul64(v, size)
+		if hi != 0 {
+			return 0, strconv.ErrRange
+		}
+		r = lo
+	}
+	// the digits beyond the 18th are below the nanosecond for all the units, so they are ignored
+	var f, scale uint64 = 0, 1
+	for k := 0; k < len(fp) && k < 18; k += 1 {
+		f = f*10 + uint64(fp[k]-'0')
+		scale *= 10
+	}
+	hi, lo := bits.Mul64(f, size)
+	quo, rem := bits.Div64(hi, lo, scale)
+	if rem >= scale-rem {
+		quo += 1
+	}
+	var carry uint64
+	if r, carry = bits.Add64(r, quo, 0); carry != 0 {
+		return 0, strconv.ErrRange
+	}
+	return r, nil
+}
+
+// FormatPercent is used to convert the given ratio to a percentage string with the given number of digits after
+// the decimal point, for example 0.125 is formatted as "12.5%" with the precision 1.
+// The special precision -1 uses the smallest number of digits necessary to represent the percentage exactly.
+func FormatPercent[K FloatingNumber](a K, precision int) string {
+	return FloatingNumberToString(a*100, 'f', precision) + "%"
+}
+
+// ParsePercent is used to convert the percentage string to the ratio, for example "12.5%" is parsed as 0.125.
+func ParsePercent(a string) (float64, error) {
+	n, u := splitNumberAndUnit(a)
+	if n == "" || u != "%" {
+		return 0, numError("ParsePercent", a, strconv.ErrSyntax)
+	}
+	f, err := StringToNumber[float64](n)
+	if err != nil {
+		return 0, numError("ParsePercent", a, err)
+	}
+	return f / 100, nil
+}
+
+// Ordinal is used to convert the given integer number to its English ordinal form, for example "1st", "22nd",
+// "103rd" or "11th".
+func Ordinal[K IntegerNumber](a K) string {
+	var s string
+	if isSigned[K]() {
+		s = strconv.FormatInt(int64(a), 10)
+	} else {
+		s = strconv.FormatUint(uint64(a), 10)
+	}
+	return s + ordinalSuffix(s)
+}
+
+// ParseOrdinal is used to convert the English ordinal form back to the integer number.
+// The suffix must be the one matching the number, so "21st" is accepted while "21th" is not.
+func ParseOrdinal[K IntegerNumber](a string) (K, error) {
+	if len(a) < 3 {
+		return 0, numError("ParseOrdinal", a, strconv.ErrSyntax)
+	}
+	n, suffix := a[:len(a)-2], a[len(a)-2:]
+	if suffix != ordinalSuffix(n) {
+		return 0, numError("ParseOrdinal", a, strconv.ErrSyntax)
+	}
+	r, err := parseDecimal[K](n)
+	if err != nil {
+		return 0, numError("ParseOrdinal", a, err)
+	}
+	return r, nil
+}
+
+// FormatCompact is used to convert the given number to the compact notation, with at most one digit after the
+// decimal point and the suffixes k (thousand), M (million), B (billion) and T (trillion), for example "1.2k".
+func FormatCompact[K Number](a K) string {
+	return formatWithUnits(float64(a), 1000, compactUnits, "")
+}
+
+// ParseCompact is used to convert the number in compact notation back to the number, for example "1.2k" is
+// parsed as 1200. Both k and K are accepted for thousands.
+func ParseCompact(a string) (float64, error) {
+	n, u := splitNumberAndUnit(a)
+	size, ok := compactUnitSizes[u]
+	if n == "" || !ok {
+		return 0, numError("ParseCompact", a, strconv.ErrSyntax)
+	}
+	f, err := StringToNumber[float64](n)
+	if err != nil {
+		return 0, numError("ParseCompact", a, err)
+	}
+	return f * size, nil
+}
+
+// parseDecimal parses the decimal integer using the size of K rather than its type, so that the named types are
+// parsed the same as their underlying types.
+func parseDecimal[K IntegerNumber](a string) (K, error) {
+	if a == "" {
+		return 0, strconv.ErrSyntax
+	}
+	return parseInteger(a, &parseOptions[K]{base: 10})
+}
+
+func integerToFloat[K IntegerNumber](a K) float64 {
+	if isSigned[K]() {
+		return float64(int64(a))
+	}
+	return float64(uint64(a))
+}
+
+func groupThousands(s, separator string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i += 1 {
+		if i > 0 && (len(s)-i)%3 == 0 {
+			b.WriteString(separator)
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+func formatWithUnits(a, base float64, units []string, space string) string {
+	sign := ""
+	if a < 0 {
+		sign, a = "-", -a
+	}
+	i := 0
+	for a >= base && i < len(units)-1 {
+		a /= base
+		i += 1
+	}
+	// the rounding can carry over to the next unit, like 999.96k which becomes 1M
+	if math.Round(a*10)/10 >= base && i < len(units)-1 {
+		a /= base
+		i += 1
+	}
+	return sign + strconv.FormatFloat(math.Round(a*10)/10, 'f', -1, 64) + space + units[i]
+}
+
+func splitNumberAndUnit(a string) (string, string) {
+	s := strings.TrimSpace(a)
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return r != '.' && r != '+' && r != '-' && !unicode.IsDigit(r)
+	})
+	if i < 0 {
+		return s, ""
+	}
+	return s[:i], strings.TrimSpace(s[i:])
+}
+
+func ordinalSuffix(s string) string {
+	l := len(s)
+	if l == 0 {
+		return ""
+	}
+	if l > 1 && s[l-2] == '1' {
+		return "th"
+	}
+	switch s[l-1] {
+	case '1':
+		return "st"
+	case '2':
+		return "nd"
+	case '3':
+		return "rd"
+	default:
+		return "th"
+	}
+}
@@ -0,0 +1,224 @@
+package numbers_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sinhashubham95/go-utils/numbers"
+	"github.com/stretchr/testify/assert"
+)
+
+type count int64
+
+type small uint8
+
+func TestFormatThousands(t *testing.T) {
+	assert.Equal(t, "0", numbers.FormatThousands(0, ","))
+	assert.Equal(t, "999", numbers.FormatThousands(999, ","))
+	assert.Equal(t, "1,000", numbers.FormatThousands(1000, ","))
+	assert.Equal(t, "-1,234,567", numbers.FormatThousands(-1234567, ","))
+	assert.Equal(t, "1 234 567", numbers.FormatThousands(1234567, " "))
+	assert.Equal(t, "18.446.744.073.709.551.615", numbers.FormatThousands(numbers.MaxUint64, "."))
+	assert.Equal(t, "-128", numbers.FormatThousands(numbers.MinInt8, ","))
+}
+
+func TestParseThousands(t *testing.T) {
+	v, err := numbers.ParseThousands[int]("1,234,567", ",")
+	assert.NoError(t, err)
+	assert.Equal(t, 1234567, v)
+	v, err = numbers.ParseThousands[int]("-12,345", ",")
+	assert.NoError(t, err)
+	assert.Equal(t, -12345, v)
+	v, err = numbers.ParseThousands[int]("+999", ",")
+	assert.NoError(t, err)
+	assert.Equal(t, 999, v)
+	v, err = numbers.ParseThousands[int]("1234", "")
+	assert.NoError(t, err)
+	assert.Equal(t, 1234, v)
+	u, err := numbers.ParseThousands[uint64]("18.446.744.073.709.551.615", ".")
+	assert.NoError(t, err)
+	assert.Equal(t, numbers.MaxUint64, u)
+
+	for _, s := range []string{"", "-", "1,23", "1234,567", ",123", "1,,234", "12a,456"} {
+		_, err = numbers.ParseThousands[int](s, ",")
+		assert.Error(t, err, s)
+	}
+	_, err = numbers.ParseThousands[int8]("1,000", ",")
+	assert.Error(t, err)
+
+	c, err := numbers.ParseThousands[count]("1,234", ",")
+	assert.NoError(t, err)
+	assert.Equal(t, count(1234), c)
+	_, err = numbers.ParseThousands[count]("abc", ",")
+	assert.Error(t, err)
+	_, err = numbers.ParseThousands[small]("1,000", ",")
+	assert.Error(t, err)
+}
+
+func TestFormatBytes(t *testing.T) {
+	assert.Equal(t, "0 B", numbers.FormatBytesSI(0))
+	assert.Equal(t, "999 B", numbers.FormatBytesSI(999))
+	assert.Equal(t, "1 kB", numbers.FormatBytesSI(1000))
+	assert.Equal(t, "3.2 MB", numbers.FormatBytesSI(3200000))
+	assert.Equal(t, "1 MB", numbers.FormatBytesSI(999960))
+	assert.Equal(t, "-1.5 GB", numbers.FormatBytesSI(-1500000000))
+	assert.Equal(t, "18.4 EB", numbers.FormatBytesSI(numbers.MaxUint64))
+
+	assert.Equal(t, "1023 B", numbers.FormatBytesIEC(1023))
+	assert.Equal(t, "1 KiB", numbers.FormatBytesIEC(1024))
+	assert.Equal(t, "1.5 KiB", numbers.FormatBytesIEC(1536))
+	assert.Equal(t, "10 MiB", numbers.FormatBytesIEC(10<<20))
+	assert.Equal(t, "8 EiB", numbers.FormatBytesIEC(numbers.MaxInt64))
+}
+
+func TestParseBytes(t *testing.T) {
+	for s, expected := range map[string]int64{
+		"512":      512,
+		"512B":     512,
+		"10MiB":    10 << 20,
+		"10 mib":   10 << 20,
+		"1.5 KiB":  1536,
+		"3.2 MB":   3200000,
+		"1kb":      1000,
+		"2G":       2000000000,
+		" 1 Ti ":   1 << 40,
+		"-4 kB":    -4000,
+		"7 EiB":    7 << 60,
+		"0.5 b":    1,
+		"0.001 KB": 1,
+	} {
+		v, err := numbers.ParseBytes(s)
+		assert.NoError(t, err, s)
+		assert.Equal(t, expected, v, s)
+	}
+	for _, s := range []string{"", "MB", "10 XB", "ten MB", "1..5 MB"} {
+		_, err := numbers.ParseBytes(s)
+		assert.Error(t, err, s)
+	}
+	for _, s := range []string{"8 EiB", "-9 EiB", "9.5 EiB", "100000000000000000000"} {
+		_, err := numbers.ParseBytes(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	assert.Equal(t, "0s", numbers.FormatDuration(0))
+	assert.Equal(t, "1d 2h 30m", numbers.FormatDuration(26*time.Hour+30*time.Minute))
+	assert.Equal(t, "1s 500ms", numbers.FormatDuration(1500*time.Millisecond))
+	assert.Equal(t, "-1m 1ns", numbers.FormatDuration(-time.Minute-time.Nanosecond))
+	assert.Equal(t, "2µs", numbers.FormatDuration(2*time.Microsecond))
+	assert.Equal(t, "-106751d 23h 47m 16s 854ms 775µs 808ns",
+		numbers.FormatDuration(time.Duration(numbers.MinInt64)))
+}
+
+func TestParseDuration(t *testing.T) {
+	for s, expected := range map[string]time.Duration{
+		"0":                 0,
+		"-0":                0,
+		"1d 2h 30m":         26*time.Hour + 30*time.Minute,
+		"1w2d":              9 * 24 * time.Hour,
+		"1.5h":              90 * time.Minute,
+		"-1s 500ms":         -1500 * time.Millisecond,
+		"2us 3µs 4μs 5ns":   9*time.Microsecond + 5*time.Nanosecond,
+		" 1 m ":             time.Minute,
+		"1d 2h 30m 1s 2ms":  26*time.Hour + 30*time.Minute + time.Second + 2*time.Millisecond,
+		"+.5d":              12 * time.Hour,
+		"15250w 1d 23h 47m": time.Duration(9223372020000000000),
+		"15250w 1d 23h 47m 16s 854ms 775us 807ns":  time.Duration(numbers.MaxInt64),
+		"-15250w 1d 23h 47m 16s 854ms 775us 808ns": time.Duration(numbers.MinInt64),
+		"106751.991167300645914d":                  time.Duration(9223372036854775807),
+		"1.0000000000000000001s":                   time.Second,
+		"0.0000000015s":                            2 * time.Nanosecond,
+	} {
+		d, err := numbers.ParseDuration(s)
+		assert.NoError(t, err, s)
+		assert.Equal(t, expected, d, s)
+	}
+	for _, s := range []string{"", "-", "1", "1x", "h", "1.2.3h", "1d-2h"} {
+		_, err := numbers.ParseDuration(s)
+		assert.Error(t, err, s)
+	}
+	for _, s := range []string{"100000w", "15250w 1d 23h 47m 16s 854ms 775us 808ns", "18446744073709551616ns"} {
+		_, err := numbers.ParseDuration(s)
+		assert.Error(t, err, s)
+	}
+
+	for _, d := range []time.Duration{time.Nanosecond, 26*time.Hour + 30*time.Minute + 1500*time.Millisecond,
+		time.Duration(numbers.MaxInt64), time.Duration(numbers.MinInt64)} {
+		p, err := numbers.ParseDuration(numbers.FormatDuration(d))
+		assert.NoError(t, err)
+		assert.Equal(t, d, p)
+	}
+}
+
+func TestFormatPercent(t *testing.T) {
+	assert.Equal(t, "12.5%", numbers.FormatPercent(0.125, 1))
+	assert.Equal(t, "12.50%", numbers.FormatPercent(0.125, 2))
+	assert.Equal(t, "13%", numbers.FormatPercent(float32(0.126), 0))
+	assert.Equal(t, "-50%", numbers.FormatPercent(-0.5, -1))
+}
+
+func TestParsePercent(t *testing.T) {
+	v, err := numbers.ParsePercent("12.5%")
+	assert.NoError(t, err)
+	assert.Equal(t, 0.125, v)
+	v, err = numbers.ParsePercent(" -50 % ")
+	assert.NoError(t, err)
+	assert.Equal(t, -0.5, v)
+	for _, s := range []string{"", "%", "12.5", "12.5 pc", "a%"} {
+		_, err = numbers.ParsePercent(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestOrdinal(t *testing.T) {
+	for v, expected := range map[int]string{
+		0: "0th", 1: "1st", 2: "2nd", 3: "3rd", 4: "4th", 11: "11th", 12: "12th", 13: "13th",
+		21: "21st", 22: "22nd", 23: "23rd", 101: "101st", 111: "111th", 1012: "1012th", -1: "-1st", -11: "-11th",
+	} {
+		assert.Equal(t, expected, numbers.Ordinal(v))
+		p, err := numbers.ParseOrdinal[int](expected)
+		assert.NoError(t, err)
+		assert.Equal(t, v, p)
+	}
+	assert.Equal(t, "18446744073709551615th", numbers.Ordinal(numbers.MaxUint64))
+	for _, s := range []string{"", "st", "1th", "21th", "11st", "3nd", "1", "x1st"} {
+		_, err := numbers.ParseOrdinal[int](s)
+		assert.Error(t, err, s)
+	}
+	_, err := numbers.ParseOrdinal[uint8]("256th")
+	assert.Error(t, err)
+
+	c, err := numbers.ParseOrdinal[count]("21st")
+	assert.NoError(t, err)
+	assert.Equal(t, count(21), c)
+	_, err = numbers.ParseOrdinal[count]("x1st")
+	assert.Error(t, err)
+	_, err = numbers.ParseOrdinal[small]("256th")
+	assert.Error(t, err)
+}
+
+func TestFormatCompact(t *testing.T) {
+	assert.Equal(t, "0", numbers.FormatCompact(0))
+	assert.Equal(t, "999", numbers.FormatCompact(999))
+	assert.Equal(t, "1.2k", numbers.FormatCompact(1234))
+	assert.Equal(t, "1M", numbers.FormatCompact(999999))
+	assert.Equal(t, "-3.5M", numbers.FormatCompact(-3500000))
+	assert.Equal(t, "2B", numbers.FormatCompact(uint64(2e9)))
+	assert.Equal(t, "1500T", numbers.FormatCompact(1.5e15))
+	assert.Equal(t, "12.3", numbers.FormatCompact(12.34))
+}
+
+func TestParseCompact(t *testing.T) {
+	for s, expected := range map[string]float64{
+		"999": 999, "1.2k": 1200, "1.2K": 1200, "3M": 3e6, "-2.5B": -2.5e9, "1 T": 1e12,
+	} {
+		v, err := numbers.ParseCompact(s)
+		assert.NoError(t, err, s)
+		assert.Equal(t, expected, v, s)
+	}
+	for _, s := range []string{"", "k", "1.2m", "1x", "a1k"} {
+		_, err := numbers.ParseCompact(s)
+		assert.Error(t, err, s)
+	}
+}
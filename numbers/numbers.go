@@ -208,3 +208,10 @@ func FloatingNumberToString[K FloatingNumber](a K, format byte, precision int) s
 		return strconv.FormatFloat(float64(a), format, precision, 64)
 	}
 }
+
+func numError(fn, s string, err error) error {
+	if e, ok := err.(*strconv.NumError); ok {
+		err = e.Err
+	}
+	return &strconv.NumError{Func: fn, Num: s, Err: err}
+}
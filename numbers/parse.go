@@ -0,0 +1,242 @@
+package numbers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/sinhashubham95/go-utils/errors"
+)
+
+// Parse error codes
+const (
+	EmptyNumberErrorCode      = "EMPTY_NUMBER"
+	InvalidNumberErrorCode    = "INVALID_NUMBER"
+	NumberOutOfRangeErrorCode = "NUMBER_OUT_OF_RANGE"
+)
+
+// Parse errors, which can be matched using errors.Is.
+var (
+	ErrEmptyNumber = &errors.Error{
+		StatusCode: http.StatusBadRequest,
+		Code:       EmptyNumberErrorCode,
+		Message:    "number is empty",
+	}
+	ErrInvalidNumber = &errors.Error{
+		StatusCode: http.StatusBadRequest,
+		Code:       InvalidNumberErrorCode,
+		Message:    "number is invalid",
+	}
+	ErrNumberOutOfRange = &errors.Error{
+		StatusCode: http.StatusBadRequest,
+		Code:       NumberOutOfRangeErrorCode,
+		Message:    "number is out of range",
+	}
+)
+
+// ParseOption is used to configure the parsing of the numbers.
+type ParseOption[K Number] func(o *parseOptions[K])
+
+type parseOptions[K Number] struct {
+	base        int
+	underscores bool
+	trim        bool
+	prefixes    bool
+	hasMin      bool
+	min         K
+	hasMax      bool
+	max         K
+}
+
+// WithBase is used to parse the integer numbers in the given base, between 2 and 36.
+// By default, the base is 10. It is ignored for floating numbers.
+func WithBase[K Number](base int) ParseOption[K] {
+	return func(o *parseOptions[K]) {
+		o.base = base
+	}
+}
+
+// WithUnderscores is used to allow underscores between the digits, like "1_000_000".
+func WithUnderscores[K Number]() ParseOption[K] {
+	return func(o *parseOptions[K]) {
+		o.underscores = true
+	}
+}
+
+// WithTrimSpace is used to ignore the leading and trailing white space.
+func WithTrimSpace[K Number]() ParseOption[K] {
+	return func(o *parseOptions[K]) {
+		o.trim = true
+	}
+}
+
+// WithPrefixes is used to allow the base prefixes 0x (hexadecimal), 0o (octal) and 0b (binary) for integer
+// numbers, which take precedence over the base.
+func WithPrefixes[K Number]() ParseOption[K] {
+	return func(o *parseOptions[K]) {
+		o.prefixes = true
+	}
+}
+
+// WithMin is used to only permit numbers greater than or equal to the given minimum.
+func WithMin[K Number](min K) ParseOption[K] {
+	return func(o *parseOptions[K]) {
+		o.hasMin, o.min = true, min
+	}
+}
+
+// WithMax is used to only permit numbers less than or equal to the given maximum.
+func WithMax[K Number](max K) ParseOption[K] {
+	return func(o *parseOptions[K]) {
+		o.hasMax, o.max = true, max
+	}
+}
+
+// WithRange is used to only permit numbers between the given minimum and maximum, both inclusive.
+func WithRange[K Number](min, max K) ParseOption[K] {
+	return func(o *parseOptions[K]) {
+		o.hasMin, o.min = true, min
+		o.hasMax, o.max = true, max
+	}
+}
+
+// Parse is used to convert the string to a number, configured using the options.
+//
+// On failure, the error is an *errors.Error with the status code 400 and one of the codes EmptyNumberErrorCode,
+// InvalidNumberErrorCode or NumberOutOfRangeErrorCode, so it matches ErrEmptyNumber, ErrInvalidNumber or
+// ErrNumberOutOfRange respectively using errors.Is.
+func Parse[K Number](s string, opts ...ParseOption[K]) (K, error) {
+	o := &parseOptions[K]{base: 10}
+	for _, opt := range opts {
+		opt(o)
+	}
+	a := s
+	if o.trim {
+		a = strings.TrimSpace(a)
+	}
+	if a == "" {
+		return 0, ErrEmptyNumber.WithMessage(fmt.Sprintf("expected %s, got an empty string", typeName[K]()))
+	}
+	if o.underscores {
+		var ok bool
+		if a, ok = removeUnderscores(a); !ok {
+			return 0, invalidNumberError[K](s)
+		}
+	}
+	var r K
+	var err error
+	if isFloat[K]() {
+		r, err = parseFloat[K](a)
+	} else {
+		r, err = parseInteger(a, o)
+	}
+	if err != nil {
+		if err == strconv.ErrRange {
+			return 0, ErrNumberOutOfRange.WithMessage(fmt.Sprintf("%q is out of range for %s", s, typeName[K]()))
+		}
+		return 0, invalidNumberError[K](s)
+	}
+	// NaN fails every comparison, so it is rejected explicitly whenever the range is limited
+	if (o.hasMin || o.hasMax) && r != r {
+		return 0, outOfRangeError(s, o)
+	}
+	if (o.hasMin && r < o.min) || (o.hasMax && r > o.max) {
+		return 0, outOfRangeError(s, o)
+	}
+	return r, nil
+}
+
+func parseFloat[K Number](a string) (K, error) {
+	f, err := strconv.ParseFloat(a, bitSize[K]())
+	if err != nil {
+		return 0, err.(*strconv.NumError).Err
+	}
+	return K(f), nil
+}
+
+func parseInteger[K Number](a string, o *parseOptions[K]) (K, error) {
+	sign, digits := "", a
+	if digits[0] == '+' || digits[0] == '-' {
+		sign, digits = digits[:1], digits[1:]
+	}
+	base := o.base
+	if o.prefixes && len(digits) > 2 && digits[0] == '0' {
+		switch digits[1] {
+		case 'x', 'X':
+			base, digits = 16, digits[2:]
+		case 'o', 'O':
+			base, digits = 8, digits[2:]
+		case 'b', 'B':
+			base, digits = 2, digits[2:]
+		}
+	}
+	if base < 2 || base > 36 || digits == "" || digits[0] == '+' || digits[0] == '-' {
+		return 0, strconv.ErrSyntax
+	}
+	if isSigned[K]() {
+		v, err := strconv.ParseInt(sign+digits, base, bitSize[K]())
+		if err != nil {
+			return 0, err.(*strconv.NumError).Err
+		}
+		return K(v), nil
+	}
+	if sign == "-" {
+		if strings.Trim(digits, "0") == "" {
+			return 0, nil
+		}
+		return 0, strconv.ErrRange
+	}
+	v, err := strconv.ParseUint(digits, base, bitSize[K]())
+	if err != nil {
+		return 0, err.(*strconv.NumError).Err
+	}
+	return K(v), nil
+}
+
+// removeUnderscores removes the underscores from the number, allowing them only between 2 digits.
+func removeUnderscores(a string) (string, bool) {
+	if !strings.Contains(a, "_") {
+		return a, true
+	}
+	var b strings.Builder
+	for i := 0; i < len(a); i += 1 {
+		if a[i] != '_' {
+			b.WriteByte(a[i])
+			continue
+		}
+		if i == 0 || i == len(a)-1 || !isAlphaNumeric(a[i-1]) || !isAlphaNumeric(a[i+1]) {
+			return "", false
+		}
+	}
+	return b.String(), true
+}
+
+func isAlphaNumeric(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isFloat[K Number]() bool {
+	half := 0.5
+	return K(half) != 0
+}
+
+func typeName[K Number]() string {
+	var a K
+	return fmt.Sprintf("%T", a)
+}
+
+func invalidNumberError[K Number](s string) error {
+	return ErrInvalidNumber.WithMessage(fmt.Sprintf("%q is not a valid %s", s, typeName[K]()))
+}
+
+func outOfRangeError[K Number](s string, o *parseOptions[K]) error {
+	switch {
+	case o.hasMin && o.hasMax:
+		return ErrNumberOutOfRange.WithMessage(fmt.Sprintf("%q is not between %v and %v", s, o.min, o.max))
+	case o.hasMin:
+		return ErrNumberOutOfRange.WithMessage(fmt.Sprintf("%q is less than %v", s, o.min))
+	default:
+		return ErrNumberOutOfRange.WithMessage(fmt.Sprintf("%q is greater than %v", s, o.max))
+	}
+}
@@ -0,0 +1,141 @@
+package numbers_test
+
+import (
+	"math"
+	"net/http"
+	"testing"
+
+	"github.com/sinhashubham95/go-utils/errors"
+	"github.com/sinhashubham95/go-utils/numbers"
+	"github.com/stretchr/testify/assert"
+)
+
+type port uint16
+
+func TestParse(t *testing.T) {
+	i, err := numbers.Parse[int]("-42")
+	assert.NoError(t, err)
+	assert.Equal(t, -42, i)
+	u8, err := numbers.Parse[uint8]("255")
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(255), u8)
+	f, err := numbers.Parse[float64]("1.5")
+	assert.NoError(t, err)
+	assert.Equal(t, 1.5, f)
+	f32, err := numbers.Parse[float32]("-0.25")
+	assert.NoError(t, err)
+	assert.Equal(t, float32(-0.25), f32)
+	p, err := numbers.Parse[port]("8080")
+	assert.NoError(t, err)
+	assert.Equal(t, port(8080), p)
+	u, err := numbers.Parse[uint]("-0")
+	assert.NoError(t, err)
+	assert.Equal(t, uint(0), u)
+}
+
+func TestParseOptions(t *testing.T) {
+	i, err := numbers.Parse("ff", numbers.WithBase[int](16))
+	assert.NoError(t, err)
+	assert.Equal(t, 255, i)
+	i, err = numbers.Parse("-0x1F", numbers.WithPrefixes[int]())
+	assert.NoError(t, err)
+	assert.Equal(t, -31, i)
+	i, err = numbers.Parse("0o17", numbers.WithPrefixes[int]())
+	assert.NoError(t, err)
+	assert.Equal(t, 15, i)
+	i, err = numbers.Parse("0B101", numbers.WithPrefixes[int](), numbers.WithBase[int](16))
+	assert.NoError(t, err)
+	assert.Equal(t, 5, i)
+	i, err = numbers.Parse("017", numbers.WithPrefixes[int]())
+	assert.NoError(t, err)
+	assert.Equal(t, 17, i)
+	i, err = numbers.Parse("1_000_000", numbers.WithUnderscores[int]())
+	assert.NoError(t, err)
+	assert.Equal(t, 1000000, i)
+	i, err = numbers.Parse("0x_ff_ff", numbers.WithUnderscores[int](), numbers.WithPrefixes[int]())
+	assert.NoError(t, err)
+	assert.Equal(t, 65535, i)
+	f, err := numbers.Parse("1_000.5", numbers.WithUnderscores[float64]())
+	assert.NoError(t, err)
+	assert.Equal(t, 1000.5, f)
+	i, err = numbers.Parse(" \t42\n", numbers.WithTrimSpace[int]())
+	assert.NoError(t, err)
+	assert.Equal(t, 42, i)
+	i, err = numbers.Parse("10", numbers.WithRange(1, 10))
+	assert.NoError(t, err)
+	assert.Equal(t, 10, i)
+	f, err = numbers.Parse("0.5", numbers.WithMin(0.0), numbers.WithMax(1.0))
+	assert.NoError(t, err)
+	assert.Equal(t, 0.5, f)
+	f, err = numbers.Parse[float64]("NaN")
+	assert.NoError(t, err)
+	assert.True(t, math.IsNaN(f))
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, c := range []struct {
+		name     string
+		parse    func() error
+		expected error
+		message  string
+	}{
+		{"empty", func() error { _, err := numbers.Parse[int](""); return err },
+			numbers.ErrEmptyNumber, "expected int, got an empty string"},
+		{"blank trimmed", func() error { _, err := numbers.Parse(" ", numbers.WithTrimSpace[int]()); return err },
+			numbers.ErrEmptyNumber, "expected int, got an empty string"},
+		{"blank", func() error { _, err := numbers.Parse[int](" "); return err },
+			numbers.ErrInvalidNumber, `" " is not a valid int`},
+		{"syntax", func() error { _, err := numbers.Parse[int]("12a"); return err },
+			numbers.ErrInvalidNumber, `"12a" is not a valid int`},
+		{"named type", func() error { _, err := numbers.Parse[port]("http"); return err },
+			numbers.ErrInvalidNumber, `"http" is not a valid numbers_test.port`},
+		{"float syntax", func() error { _, err := numbers.Parse[float64]("1.2.3"); return err },
+			numbers.ErrInvalidNumber, `"1.2.3" is not a valid float64`},
+		{"sign only", func() error { _, err := numbers.Parse[int]("-"); return err },
+			numbers.ErrInvalidNumber, `"-" is not a valid int`},
+		{"double sign", func() error { _, err := numbers.Parse[uint]("+-1"); return err },
+			numbers.ErrInvalidNumber, `"+-1" is not a valid uint`},
+		{"underscores not allowed", func() error { _, err := numbers.Parse[int]("1_000"); return err },
+			numbers.ErrInvalidNumber, `"1_000" is not a valid int`},
+		{"leading underscore", func() error {
+			_, err := numbers.Parse("_1", numbers.WithUnderscores[int]())
+			return err
+		}, numbers.ErrInvalidNumber, `"_1" is not a valid int`},
+		{"double underscore", func() error {
+			_, err := numbers.Parse("1__0", numbers.WithUnderscores[int]())
+			return err
+		}, numbers.ErrInvalidNumber, `"1__0" is not a valid int`},
+		{"prefixes not allowed", func() error { _, err := numbers.Parse[int]("0x10"); return err },
+			numbers.ErrInvalidNumber, `"0x10" is not a valid int`},
+		{"prefix only", func() error { _, err := numbers.Parse("0x", numbers.WithPrefixes[int]()); return err },
+			numbers.ErrInvalidNumber, `"0x" is not a valid int`},
+		{"invalid base", func() error { _, err := numbers.Parse("1", numbers.WithBase[int](37)); return err },
+			numbers.ErrInvalidNumber, `"1" is not a valid int`},
+		{"digit outside base", func() error { _, err := numbers.Parse("2", numbers.WithBase[int](2)); return err },
+			numbers.ErrInvalidNumber, `"2" is not a valid int`},
+		{"type overflow", func() error { _, err := numbers.Parse[int8]("128"); return err },
+			numbers.ErrNumberOutOfRange, `"128" is out of range for int8`},
+		{"negative unsigned", func() error { _, err := numbers.Parse[uint8]("-1"); return err },
+			numbers.ErrNumberOutOfRange, `"-1" is out of range for uint8`},
+		{"float overflow", func() error { _, err := numbers.Parse[float32]("1e39"); return err },
+			numbers.ErrNumberOutOfRange, `"1e39" is out of range for float32`},
+		{"range", func() error { _, err := numbers.Parse("11", numbers.WithRange(1, 10)); return err },
+			numbers.ErrNumberOutOfRange, `"11" is not between 1 and 10`},
+		{"min", func() error { _, err := numbers.Parse("-1", numbers.WithMin(0)); return err },
+			numbers.ErrNumberOutOfRange, `"-1" is less than 0`},
+		{"max", func() error { _, err := numbers.Parse("1.5", numbers.WithMax(1.0)); return err },
+			numbers.ErrNumberOutOfRange, `"1.5" is greater than 1`},
+		{"NaN with range", func() error { _, err := numbers.Parse("NaN", numbers.WithRange(0.0, 1.0)); return err },
+			numbers.ErrNumberOutOfRange, `"NaN" is not between 0 and 1`},
+		{"NaN with min", func() error { _, err := numbers.Parse("nan", numbers.WithMin[float32](0)); return err },
+			numbers.ErrNumberOutOfRange, `"nan" is less than 0`},
+	} {
+		err := c.parse()
+		assert.Error(t, err, c.name)
+		assert.True(t, errors.Is(err, c.expected), c.name)
+		var e *errors.Error
+		assert.True(t, errors.As(err, &e), c.name)
+		assert.Equal(t, http.StatusBadRequest, e.StatusCode, c.name)
+		assert.Equal(t, c.message, e.Message, c.name)
+	}
+}
@@ -0,0 +1,233 @@
+package numbers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/sinhashubham95/go-utils/errors"
+)
+
+// limits of the radix conversions
+const (
+	MinRadix = 2
+	MaxRadix = 64
+	MinRoman = 1
+	MaxRoman = 3999
+)
+
+// Alphabets for the commonly used radix conversions.
+var (
+	// Base32 is the Crockford base 32 alphabet, which excludes the letters I, L, O and U.
+	Base32 = mustAlphabet("0123456789ABCDEFGHJKMNPQRSTVWXYZ")
+	// Base36 is the alphabet of the digits followed by the lower case letters.
+	Base36 = mustAlphabet("0123456789abcdefghijklmnopqrstuvwxyz")
+	// Base58 is the bitcoin base 58 alphabet, which excludes the characters 0, O, I and l.
+	Base58 = mustAlphabet("123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz")
+	// Base62 is the alphabet of the digits followed by the upper case and the lower case letters.
+	Base62 = mustAlphabet("0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz")
+)
+
+var romanNumerals = []struct {
+	value  int
+	symbol string
+}{
+	{1000, "M"}, {900, "CM"}, {500, "D"}, {400, "CD"}, {100, "C"}, {90, "XC"},
+	{50, "L"}, {40, "XL"}, {10, "X"}, {9, "IX"}, {5, "V"}, {4, "IV"}, {1, "I"},
+}
+
+// Alphabet is the set of digits used to convert the integer numbers to and from a radix.
+// The radix is the number of digits in the alphabet.
+type Alphabet struct {
+	digits string
+	values [256]int8
+}
+
+// NewAlphabet is used to create the alphabet from the given digits, ordered from the digit for 0 upwards.
+//
+// The digits must be between 2 and 64 distinct printable ASCII characters, other than the sign characters + and -.
+// If the alphabet does not use both the cases of any letter, parsing with it ignores the case.
+func NewAlphabet(digits string) (*Alphabet, error) {
+	if len(digits) < MinRadix || len(digits) > MaxRadix {
+		return nil, errors.New(fmt.Sprintf("alphabet must have between %d and %d digits, got %d",
+			MinRadix, MaxRadix, len(digits)))
+	}
+	a := &Alphabet{digits: digits}
+	for i := range a.values {
+		a.values[i] = -1
+	}
+	for i := 0; i < len(digits); i += 1 {
+		c := digits[i]
+		if c <= ' ' || c > '~' || c == '+' || c == '-' {
+			return nil, errors.New(fmt.Sprintf("alphabet digit %q is not allowed", c))
+		}
+		if a.values[c] >= 0 {
+			return nil, errors.New(fmt.Sprintf("alphabet digit %q is repeated", c))
+		}
+		a.values[c] = int8(i)
+	}
+	if !hasBothCases(digits) {
+		for i := 0; i < len(digits); i += 1 {
+			if o := swapCase(digits[i]); o != digits[i] {
+				a.values[o] = int8(i)
+			}
+		}
+	}
+	return a, nil
+}
+
+// Radix returns the radix of the alphabet, which is the number of digits in it.
+func (a *Alphabet) Radix() int {
+	return len(a.digits)
+}
+
+// Digits returns the digits of the alphabet.
+func (a *Alphabet) Digits() string {
+	return a.digits
+}
+
+// FormatRadix is used to convert the given integer number to string in the radix of the alphabet.
+// Negative numbers are prefixed by the sign -.
+//
+// ParseRadix converts the result back to the same number.
+func FormatRadix[K IntegerNumber](a K, alphabet *Alphabet) string {
+	u, negative := toMagnitude(a)
+	if u == 0 {
+		return alphabet.digits[:1]
+	}
+	radix := uint64(len(alphabet.digits))
+	var buf [65]byte
+	i := len(buf)
+	for u > 0 {
+		i -= 1
+		buf[i] = alphabet.digits[u%radix]
+		u /= radix
+	}
+	if negative {
+		i -= 1
+		buf[i] = '-'
+	}
+	return string(buf[i:])
+}
+
+// ParseRadix is used to convert the string in the radix of the alphabet to an integer number.
+// It may have a leading sign + or -.
+//
+// The error is a *strconv.NumError, with strconv.ErrSyntax if the string has a character that is not a digit of
+// the alphabet, and strconv.ErrRange if the number overflows the type.
+func ParseRadix[K IntegerNumber](s string, alphabet *Alphabet) (K, error) {
+	digits := s
+	negative := false
+	if digits != "" && (digits[0] == '+' || digits[0] == '-') {
+		negative = digits[0] == '-'
+		digits = digits[1:]
+	}
+	if digits == "" {
+		return 0, numError("ParseRadix", s, strconv.ErrSyntax)
+	}
+	limit := bitMask(bitSize[K]())
+	if isSigned[K]() {
+		limit >>= 1
+		if negative {
+			limit += 1
+		}
+	} else if negative {
+		limit = 0
+	}
+	radix := uint64(len(alphabet.digits))
+	var u uint64
+	for i := 0; i < len(digits); i += 1 {
+		d := alphabet.values[digits[i]]
+		if d < 0 {
+			return 0, numError("ParseRadix", s, strconv.ErrSyntax)
+		}
+		if uint64(d) > limit || u > (limit-uint64(d))/radix {
+			return 0, numError("ParseRadix", s, strconv.ErrRange)
+		}
+		u = u*radix + uint64(d)
+	}
+	if negative {
+		return K(-u), nil
+	}
+	return K(u), nil
+}
+
+// ToRoman is used to convert the given integer number to the Roman numeral, for example 1994 to "MCMXCIV".
+//
+// The number must be between 1 and 3999, otherwise the error is a *strconv.NumError with strconv.ErrRange.
+func ToRoman[K IntegerNumber](a K) (string, error) {
+	u, negative := toMagnitude(a)
+	if negative || u < MinRoman || u > MaxRoman {
+		return "", numError("ToRoman", FormatThousands(a, ""), strconv.ErrRange)
+	}
+	var b strings.Builder
+	v := int(u)
+	for _, r := range romanNumerals {
+		for v >= r.value {
+			b.WriteString(r.symbol)
+			v -= r.value
+		}
+	}
+	return b.String(), nil
+}
+
+// FromRoman is used to convert the Roman numeral to the integer number, for example "MCMXCIV" to 1994.
+//
+// Only the canonical upper case numerals produced by ToRoman are accepted, so "IIII" or "IC" are rejected with a
+// *strconv.NumError having strconv.ErrSyntax. If the number overflows the type, it has strconv.ErrRange.
+func FromRoman[K IntegerNumber](s string) (K, error) {
+	v := 0
+	rest := s
+	for _, r := range romanNumerals {
+		for strings.HasPrefix(rest, r.symbol) {
+			v += r.value
+			rest = rest[len(r.symbol):]
+		}
+	}
+	if rest != "" || v == 0 {
+		return 0, numError("FromRoman", s, strconv.ErrSyntax)
+	}
+	if canonical, _ := ToRoman(v); canonical != s {
+		return 0, numError("FromRoman", s, strconv.ErrSyntax)
+	}
+	if u, _ := toMagnitude(K(v)); u != uint64(v) {
+		return 0, numError("FromRoman", s, strconv.ErrRange)
+	}
+	return K(v), nil
+}
+
+func mustAlphabet(digits string) *Alphabet {
+	a, err := NewAlphabet(digits)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+func hasBothCases(digits string) bool {
+	for i := 0; i < len(digits); i += 1 {
+		if o := swapCase(digits[i]); o != digits[i] && strings.IndexByte(digits, o) >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func swapCase(c byte) byte {
+	switch {
+	case c >= 'a' && c <= 'z':
+		return c - 'a' + 'A'
+	case c >= 'A' && c <= 'Z':
+		return c - 'A' + 'a'
+	default:
+		return c
+	}
+}
+
+// toMagnitude returns the absolute value of the number along with whether it is negative.
+func toMagnitude[K IntegerNumber](a K) (uint64, bool) {
+	if a < 0 {
+		return -uint64(int64(a)), true
+	}
+	return uint64(a), false
+}
@@ -0,0 +1,149 @@
+package numbers_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/sinhashubham95/go-utils/errors"
+	"github.com/sinhashubham95/go-utils/numbers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAlphabet(t *testing.T) {
+	a, err := numbers.NewAlphabet("01")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, a.Radix())
+	assert.Equal(t, "01", a.Digits())
+	a, err = numbers.NewAlphabet("ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789._")
+	assert.NoError(t, err)
+	assert.Equal(t, 64, a.Radix())
+
+	for _, digits := range []string{"", "0", "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz!@#",
+		"001", "01-", "0+", "0 1", "01\x80"} {
+		_, err = numbers.NewAlphabet(digits)
+		assert.Error(t, err, digits)
+	}
+	assert.Equal(t, 32, numbers.Base32.Radix())
+	assert.Equal(t, 36, numbers.Base36.Radix())
+	assert.Equal(t, 58, numbers.Base58.Radix())
+	assert.Equal(t, 62, numbers.Base62.Radix())
+}
+
+func TestFormatRadix(t *testing.T) {
+	assert.Equal(t, "0", numbers.FormatRadix(0, numbers.Base36))
+	assert.Equal(t, "1", numbers.FormatRadix(0, numbers.Base58))
+	assert.Equal(t, "z", numbers.FormatRadix(35, numbers.Base36))
+	assert.Equal(t, "10", numbers.FormatRadix(36, numbers.Base36))
+	assert.Equal(t, "-zz", numbers.FormatRadix(-1295, numbers.Base36))
+	assert.Equal(t, "Z", numbers.FormatRadix(31, numbers.Base32))
+	assert.Equal(t, "z", numbers.FormatRadix(61, numbers.Base62))
+	assert.Equal(t, "LygHa16AHYF", numbers.FormatRadix(numbers.MaxUint64, numbers.Base62))
+	assert.Equal(t, "-AzL8n0Y58m8", numbers.FormatRadix(numbers.MinInt64, numbers.Base62))
+	assert.Equal(t, "3yR", numbers.FormatRadix(10000, numbers.Base58))
+	binary, _ := numbers.NewAlphabet("01")
+	assert.Equal(t, "1111111111111111111111111111111111111111111111111111111111111111",
+		numbers.FormatRadix(numbers.MaxUint64, binary))
+	assert.Equal(t, "-10000000", numbers.FormatRadix(numbers.MinInt8, binary))
+}
+
+func TestParseRadix(t *testing.T) {
+	v, err := numbers.ParseRadix[int]("zz", numbers.Base36)
+	assert.NoError(t, err)
+	assert.Equal(t, 1295, v)
+	v, err = numbers.ParseRadix[int]("ZZ", numbers.Base36)
+	assert.NoError(t, err)
+	assert.Equal(t, 1295, v)
+	v, err = numbers.ParseRadix[int]("-zZ", numbers.Base36)
+	assert.NoError(t, err)
+	assert.Equal(t, -1295, v)
+	v, err = numbers.ParseRadix[int]("+z", numbers.Base62)
+	assert.NoError(t, err)
+	assert.Equal(t, 61, v)
+	v, err = numbers.ParseRadix[int]("z", numbers.Base32)
+	assert.NoError(t, err)
+	assert.Equal(t, 31, v)
+	u8, err := numbers.ParseRadix[uint8]("-0", numbers.Base36)
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(0), u8)
+
+	for _, c := range []struct {
+		s        string
+		alphabet *numbers.Alphabet
+		err      error
+	}{
+		{"", numbers.Base36, strconv.ErrSyntax},
+		{"-", numbers.Base36, strconv.ErrSyntax},
+		{"0", numbers.Base58, strconv.ErrSyntax},
+		{"I", numbers.Base32, strconv.ErrSyntax},
+		{"a b", numbers.Base36, strconv.ErrSyntax},
+		{"LygHa16AHYG", numbers.Base62, strconv.ErrRange},
+		{"zzzzzzzzzzzzzzzzzzzzzzz", numbers.Base62, strconv.ErrRange},
+	} {
+		_, err = numbers.ParseRadix[int64](c.s, c.alphabet)
+		assert.Error(t, err, c.s)
+		assert.True(t, errors.Is(err, c.err), c.s)
+	}
+	_, err = numbers.ParseRadix[uint8]("-1", numbers.Base36)
+	assert.True(t, errors.Is(err, strconv.ErrRange))
+	_, err = numbers.ParseRadix[int8]("3k", numbers.Base36)
+	assert.True(t, errors.Is(err, strconv.ErrRange))
+	i8, err := numbers.ParseRadix[int8]("-3k", numbers.Base36)
+	assert.NoError(t, err)
+	assert.Equal(t, numbers.MinInt8, i8)
+	i8, err = numbers.ParseRadix[int8]("3j", numbers.Base36)
+	assert.NoError(t, err)
+	assert.Equal(t, numbers.MaxInt8, i8)
+}
+
+func TestRadixRoundTrip(t *testing.T) {
+	custom, err := numbers.NewAlphabet("ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789._")
+	assert.NoError(t, err)
+	for _, alphabet := range []*numbers.Alphabet{numbers.Base32, numbers.Base36, numbers.Base58, numbers.Base62,
+		custom} {
+		for _, v := range []int64{0, 1, -1, 42, 1 << 40, numbers.MaxInt64, numbers.MinInt64} {
+			p, err := numbers.ParseRadix[int64](numbers.FormatRadix(v, alphabet), alphabet)
+			assert.NoError(t, err)
+			assert.Equal(t, v, p)
+		}
+		for _, v := range []uint64{0, 7, numbers.MaxUint64} {
+			p, err := numbers.ParseRadix[uint64](numbers.FormatRadix(v, alphabet), alphabet)
+			assert.NoError(t, err)
+			assert.Equal(t, v, p)
+		}
+	}
+}
+
+func TestRoman(t *testing.T) {
+	for v, expected := range map[int]string{
+		1: "I", 4: "IV", 9: "IX", 14: "XIV", 40: "XL", 90: "XC", 400: "CD", 900: "CM", 1994: "MCMXCIV",
+		2024: "MMXXIV", 3999: "MMMCMXCIX",
+	} {
+		r, err := numbers.ToRoman(v)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, r)
+		p, err := numbers.FromRoman[int](r)
+		assert.NoError(t, err)
+		assert.Equal(t, v, p)
+	}
+	for _, v := range []int{0, -1, 4000} {
+		_, err := numbers.ToRoman(v)
+		assert.True(t, errors.Is(err, strconv.ErrRange), v)
+	}
+	for _, s := range []string{"", "IIII", "IC", "VV", "MMMM", "iv", "XIIV", "ABC", "IXI"} {
+		_, err := numbers.FromRoman[int](s)
+		assert.True(t, errors.Is(err, strconv.ErrSyntax), s)
+	}
+	_, err := numbers.FromRoman[int8]("CC")
+	assert.True(t, errors.Is(err, strconv.ErrRange))
+	u8, err := numbers.FromRoman[uint8]("CCLV")
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(255), u8)
+
+	for v := numbers.MinRoman; v <= numbers.MaxRoman; v += 1 {
+		r, err := numbers.ToRoman(v)
+		assert.NoError(t, err)
+		p, err := numbers.FromRoman[int](r)
+		assert.NoError(t, err)
+		assert.Equal(t, v, p)
+	}
+}
@@ -0,0 +1,49 @@
+package retry
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sinhashubham95/go-utils/errors"
+)
+
+// IsRetryable is used to decide whether the operation failing with the error can be retried.
+//
+// The errors canceling the context or exceeding its deadline are not retryable. Otherwise, the error is retryable
+// if an *errors.Error in its chain is marked as retryable, or if its status code is 5xx or 429. The errors without
+// an *errors.Error or an *errors.MultiError in the chain are retryable if their category is, as per errors.Classify,
+// like the failures of the network.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if isMarkedRetryable(err) {
+		return true
+	}
+	var e *errors.Error
+	var m *errors.MultiError
+	if errors.As(err, &e) || errors.As(err, &m) {
+		s := errors.StatusCode(err)
+		return s >= http.StatusInternalServerError || s == http.StatusTooManyRequests
+	}
+	return errors.Classify(err).Retryable()
+}
+
+// isMarkedRetryable returns whether any *errors.Error in the tree of the error is marked as retryable.
+func isMarkedRetryable(err error) bool {
+	for err != nil {
+		if e, ok := err.(*errors.Error); ok && e.Retryable {
+			return true
+		}
+		if m, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, c := range m.Unwrap() {
+				if isMarkedRetryable(c) {
+					return true
+				}
+			}
+			return false
+		}
+		err = errors.Unwrap(err)
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+package retry_test
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/sinhashubham95/go-utils/errors"
+	"github.com/sinhashubham95/go-utils/retry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsRetryable(t *testing.T) {
+	assert.False(t, retry.IsRetryable(nil))
+	assert.False(t, retry.IsRetryable(stderrors.New("naruto")))
+	assert.True(t, retry.IsRetryable(io.EOF))
+	assert.True(t, retry.IsRetryable(&net.OpError{Op: "dial", Err: stderrors.New("connection refused")}))
+	assert.False(t, retry.IsRetryable(context.Canceled))
+	assert.False(t, retry.IsRetryable(fmt.Errorf("call: %w", context.DeadlineExceeded)))
+
+	assert.True(t, retry.IsRetryable(errors.New("naruto")))
+	assert.True(t, retry.IsRetryable(&errors.Error{StatusCode: http.StatusInternalServerError}))
+	assert.True(t, retry.IsRetryable(&errors.Error{StatusCode: http.StatusNotImplemented}))
+	assert.True(t, retry.IsRetryable(&errors.Error{StatusCode: http.StatusInsufficientStorage}))
+	assert.True(t, retry.IsRetryable(&errors.Error{StatusCode: http.StatusGatewayTimeout}))
+	assert.True(t, retry.IsRetryable(&errors.Error{StatusCode: http.StatusServiceUnavailable}))
+	assert.True(t, retry.IsRetryable(&errors.Error{StatusCode: http.StatusTooManyRequests}))
+	assert.True(t, retry.IsRetryable(fmt.Errorf("call: %w", &errors.Error{StatusCode: http.StatusBadGateway})))
+	assert.False(t, retry.IsRetryable(&errors.Error{StatusCode: http.StatusNotFound}))
+	assert.False(t, retry.IsRetryable(&errors.Error{StatusCode: http.StatusBadRequest}))
+
+	assert.True(t, retry.IsRetryable(&errors.Error{StatusCode: http.StatusConflict, Retryable: true}))
+	assert.True(t, retry.IsRetryable(errors.Wrap(&errors.Error{StatusCode: http.StatusBadRequest},
+		&errors.Error{StatusCode: http.StatusConflict, Retryable: true})))
+	assert.True(t, retry.IsRetryable(errors.Join(&errors.Error{StatusCode: http.StatusBadRequest},
+		&errors.Error{StatusCode: http.StatusConflict, Retryable: true})))
+	assert.True(t, retry.IsRetryable(errors.Join(&errors.Error{StatusCode: http.StatusBadRequest},
+		&errors.Error{StatusCode: http.StatusServiceUnavailable})))
+	assert.False(t, retry.IsRetryable(errors.Join(&errors.Error{StatusCode: http.StatusBadRequest},
+		&errors.Error{StatusCode: http.StatusNotFound})))
+	assert.False(t, retry.IsRetryable(errors.Join(&errors.Error{StatusCode: http.StatusServiceUnavailable},
+		context.Canceled)))
+}
@@ -0,0 +1,250 @@
+// Package retry is used to retry the operations failing with the retryable errors,
+// waiting with an exponential backoff between the attempts.
+package retry
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/sinhashubham95/go-utils/errors"
+	"github.com/sinhashubham95/go-utils/log"
+)
+
+// codes of the errors returned when the operation does not succeed after more than one attempt
+const (
+	// ExhaustedErrorCode is the code of the error returned when all the attempts of the operation fail.
+	ExhaustedErrorCode = "RETRY_EXHAUSTED"
+	// AbortedErrorCode is the code of the error returned when the operation is not retried any more before all the
+	// attempts are made, because it fails with an error which is not retryable or the context is done.
+	AbortedErrorCode = "RETRY_ABORTED"
+)
+
+// defaults of the options
+const (
+	DefaultMaxAttempts  = 3
+	DefaultInitialDelay = 100 * time.Millisecond
+	DefaultMaxDelay     = 10 * time.Second
+	DefaultMultiplier   = 2
+	DefaultJitter       = 0.5
+)
+
+// Attempt is the outcome of an attempt of the operation.
+type Attempt struct {
+	// Number is the number of the attempt, starting from 1.
+	Number int
+	// Err is the error with which the attempt failed, nil if it succeeded.
+	Err error
+	// Delay is the time waited before the next attempt.
+	Delay time.Duration
+	// Last tells whether there is no next attempt.
+	Last bool
+	// Duration is the time taken by the attempt.
+	Duration time.Duration
+}
+
+// Hook is called after each attempt of the operation.
+type Hook func(ctx context.Context, attempt Attempt)
+
+// Option is used to configure the retries.
+type Option func(*options)
+
+type options struct {
+	maxAttempts    int
+	initialDelay   time.Duration
+	maxDelay       time.Duration
+	multiplier     float64
+	jitter         float64
+	attemptTimeout time.Duration
+	retryable      func(error) bool
+	hooks          []Hook
+}
+
+// WithMaxAttempts is used to set the maximum number of attempts, 3 by default.
+// It panics if the number is less than 1.
+func WithMaxAttempts(n int) Option {
+	if n < 1 {
+		panic(fmt.Sprintf("max attempts must be at least 1, got %d", n))
+	}
+	return func(o *options) {
+		o.maxAttempts = n
+	}
+}
+
+// WithBackoff is used to set the delay before the second attempt, multiplied by the multiplier for each of the
+// next ones up to the maximum delay. By default, it starts at 100ms, doubles each time and is at most 10s.
+// It panics if the delays are negative or the multiplier is less than 1.
+func WithBackoff(initial, max time.Duration, multiplier float64) Option {
+	if initial < 0 || max < 0 || multiplier < 1 {
+		panic(fmt.Sprintf("invalid backoff %s, %s and %v", initial, max, multiplier))
+	}
+	return func(o *options) {
+		o.initialDelay = initial
+		o.maxDelay = max
+		o.multiplier = multiplier
+	}
+}
+
+// WithJitter is used to set the fraction of the delay which is randomised, 0.5 by default. The delay is reduced
+// by a random amount up to this fraction of it, so that the clients failing together do not retry together.
+// It panics if the fraction is not between 0 and 1.
+func WithJitter(fraction float64) Option {
+	if fraction < 0 || fraction > 1 {
+		panic(fmt.Sprintf("jitter must be between 0 and 1, got %v", fraction))
+	}
+	return func(o *options) {
+		o.jitter = fraction
+	}
+}
+
+// WithAttemptTimeout is used to limit the time taken by each attempt. The attempts timing out are retried,
+// unless the context of the operation is done as well. By default, only the context of the operation limits them.
+func WithAttemptTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.attemptTimeout = timeout
+	}
+}
+
+// WithRetryable is used to decide whether the operation failing with an error is retried, IsRetryable by default.
+func WithRetryable(retryable func(error) bool) Option {
+	return func(o *options) {
+		o.retryable = retryable
+	}
+}
+
+// WithHooks is used to add the hooks called after each attempt.
+func WithHooks(hooks ...Hook) Option {
+	return func(o *options) {
+		o.hooks = append(o.hooks, hooks...)
+	}
+}
+
+// LogHook is used to create the hook logging each attempt of the operation using the log package.
+// The successful attempts are logged at debug level, the failed attempts to be retried as warnings, and the last
+// failed one as per log.ErrorWarn.
+func LogHook(operation string) Hook {
+	return func(ctx context.Context, a Attempt) {
+		if a.Err == nil {
+			log.Debug(ctx).Str("operation", operation).Int("attempt", a.Number).
+				Str("duration", a.Duration.String()).Msg("attempt succeeded")
+			return
+		}
+		if !a.Last {
+			log.Warn(ctx).Err(a.Err).Str("operation", operation).Int("attempt", a.Number).
+				Str("delay", a.Delay.String()).Msg("attempt failed, retrying")
+			return
+		}
+		log.ErrorWarn(ctx, a.Err).Str("operation", operation).Int("attempt", a.Number).Msg("attempt failed")
+	}
+}
+
+// Do is used to call the function until it succeeds, fails with an error which is not retryable, the attempts are
+// exhausted, or the context is done, waiting with an exponential backoff between the attempts.
+//
+// If it does not succeed after the first attempt, without waiting for the next one, the error is the one of the
+// attempt as it is. Otherwise, the error is an *errors.MultiError listing the error of each attempt, followed by
+// the error of the context if it is done while waiting, with the code RETRY_EXHAUSTED if all the attempts are made,
+// or RETRY_ABORTED if it stops before, because of an error which is not retryable or the deadline of the context.
+func Do(ctx context.Context, fn func(ctx context.Context) error, opts ...Option) error {
+	_, err := DoValue(ctx, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	}, opts...)
+	return err
+}
+
+// DoValue is the same as Do, for the functions returning a value along with the error.
+func DoValue[T any](ctx context.Context, fn func(ctx context.Context) (T, error), opts ...Option) (T, error) {
+	o := &options{
+		maxAttempts:  DefaultMaxAttempts,
+		initialDelay: DefaultInitialDelay,
+		maxDelay:     DefaultMaxDelay,
+		multiplier:   DefaultMultiplier,
+		jitter:       DefaultJitter,
+		retryable:    IsRetryable,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	var zero T
+	failures := &errors.MultiError{Code: ExhaustedErrorCode}
+	delay := float64(o.initialDelay)
+	for n := 1; ; n += 1 {
+		start := time.Now()
+		v, err := attempt(ctx, fn, o.attemptTimeout)
+		a := Attempt{Number: n, Err: err, Duration: time.Since(start), Last: true}
+		if err == nil {
+			o.call(ctx, a)
+			return v, nil
+		}
+		failures.Errors = append(failures.Errors, err)
+		retryable := ctx.Err() == nil && (o.retryable(err) || o.timedOut(ctx, err))
+		retry := n < o.maxAttempts && retryable
+		if retry {
+			a.Delay = o.jittered(delay)
+			// there is no point in waiting if the deadline passes before the next attempt
+			if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < a.Delay {
+				retry = false
+				a.Delay = 0
+			}
+		}
+		a.Last = !retry
+		o.call(ctx, a)
+		if !retry {
+			if n == 1 {
+				return zero, err
+			}
+			if n < o.maxAttempts || !retryable {
+				failures.Code = AbortedErrorCode
+			}
+			return zero, failures.WithMessage(fmt.Sprintf("failed after %d attempts", n))
+		}
+		if err = wait(ctx, a.Delay); err != nil {
+			failures.Errors = append(failures.Errors, err)
+			failures.Code = AbortedErrorCode
+			return zero, failures.WithMessage(fmt.Sprintf("failed after %d attempts", n))
+		}
+		delay *= o.multiplier
+		if delay > float64(o.maxDelay) {
+			delay = float64(o.maxDelay)
+		}
+	}
+}
+
+func attempt[T any](ctx context.Context, fn func(ctx context.Context) (T, error), timeout time.Duration) (T, error) {
+	if timeout <= 0 {
+		return fn(ctx)
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return fn(ctx)
+}
+
+func wait(ctx context.Context, delay time.Duration) error {
+	t := time.NewTimer(delay)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// timedOut returns whether the attempt failed because of its own timeout rather than the one of the operation.
+func (o *options) timedOut(ctx context.Context, err error) bool {
+	return o.attemptTimeout > 0 && ctx.Err() == nil && errors.Is(err, context.DeadlineExceeded)
+}
+
+func (o *options) jittered(delay float64) time.Duration {
+	if delay > float64(o.maxDelay) {
+		delay = float64(o.maxDelay)
+	}
+	return time.Duration(delay * (1 - o.jitter*rand.Float64()))
+}
+
+func (o *options) call(ctx context.Context, a Attempt) {
+	for _, h := range o.hooks {
+		h(ctx, a)
+	}
+}
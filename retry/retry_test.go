@@ -0,0 +1,226 @@
+package retry_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sinhashubham95/go-utils/errors"
+	"github.com/sinhashubham95/go-utils/log"
+	"github.com/sinhashubham95/go-utils/log/logtest"
+	"github.com/sinhashubham95/go-utils/retry"
+	"github.com/stretchr/testify/assert"
+)
+
+var errUnavailable = &errors.Error{StatusCode: http.StatusServiceUnavailable, Code: "UNAVAILABLE"}
+
+var errNotFound = &errors.Error{StatusCode: http.StatusNotFound, Code: "NOT_FOUND"}
+
+func TestDo(t *testing.T) {
+	calls := 0
+	var attempts []retry.Attempt
+	err := retry.Do(context.Background(), func(ctx context.Context) error {
+		calls += 1
+		if calls < 3 {
+			return errUnavailable
+		}
+		return nil
+	}, retry.WithBackoff(time.Millisecond, time.Millisecond, 2), retry.WithJitter(0),
+		retry.WithHooks(func(ctx context.Context, a retry.Attempt) {
+			attempts = append(attempts, a)
+		}))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+	assert.Len(t, attempts, 3)
+	assert.Equal(t, 1, attempts[0].Number)
+	assert.Equal(t, errUnavailable, attempts[0].Err)
+	assert.Equal(t, time.Millisecond, attempts[0].Delay)
+	assert.False(t, attempts[0].Last)
+	assert.Equal(t, 3, attempts[2].Number)
+	assert.NoError(t, attempts[2].Err)
+	assert.True(t, attempts[2].Last)
+}
+
+func TestDoExhausted(t *testing.T) {
+	calls := 0
+	err := retry.Do(context.Background(), func(ctx context.Context) error {
+		calls += 1
+		return errUnavailable.WithMessage(fmt.Sprintf("attempt %d", calls))
+	}, retry.WithMaxAttempts(4), retry.WithBackoff(0, 0, 1))
+	assert.Equal(t, 4, calls)
+	m, ok := err.(*errors.MultiError)
+	assert.True(t, ok)
+	assert.Equal(t, retry.ExhaustedErrorCode, m.Code)
+	assert.Equal(t, "failed after 4 attempts:\n\t* UNAVAILABLE\n\t* UNAVAILABLE\n\t* UNAVAILABLE\n\t* UNAVAILABLE",
+		m.Error())
+	assert.Equal(t, "attempt 4", m.Errors[3].(*errors.Error).Message)
+	assert.Equal(t, http.StatusServiceUnavailable, errors.StatusCode(err))
+	assert.True(t, errors.Is(err, errUnavailable))
+}
+
+func TestDoNotRetryable(t *testing.T) {
+	calls := 0
+	err := retry.Do(context.Background(), func(ctx context.Context) error {
+		calls += 1
+		if calls == 1 {
+			return errUnavailable
+		}
+		return errNotFound
+	}, retry.WithBackoff(0, 0, 1))
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, 2, err.(*errors.MultiError).Len())
+	assert.Equal(t, retry.AbortedErrorCode, err.(*errors.MultiError).Code)
+	assert.True(t, errors.Is(err, errNotFound))
+
+	calls = 0
+	err = retry.Do(context.Background(), func(ctx context.Context) error {
+		calls += 1
+		return errNotFound
+	}, retry.WithBackoff(0, 0, 1))
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, errNotFound, err)
+	assert.Equal(t, http.StatusNotFound, errors.StatusCode(err))
+
+	calls = 0
+	err = retry.Do(context.Background(), func(ctx context.Context) error {
+		calls += 1
+		return errNotFound
+	}, retry.WithRetryable(func(err error) bool { return true }), retry.WithBackoff(0, 0, 1))
+	assert.Equal(t, retry.DefaultMaxAttempts, calls)
+	assert.Error(t, err)
+}
+
+func TestDoValue(t *testing.T) {
+	calls := 0
+	v, err := retry.DoValue(context.Background(), func(ctx context.Context) (string, error) {
+		calls += 1
+		if calls == 1 {
+			return "", errUnavailable.WithRetryable(true)
+		}
+		return "naruto", nil
+	}, retry.WithBackoff(0, 0, 1))
+	assert.NoError(t, err)
+	assert.Equal(t, "naruto", v)
+
+	v, err = retry.DoValue(context.Background(), func(ctx context.Context) (string, error) {
+		return "partial", errNotFound
+	})
+	assert.Error(t, err)
+	assert.Equal(t, "", v)
+}
+
+func TestDoContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	err := retry.Do(ctx, func(ctx context.Context) error {
+		calls += 1
+		cancel()
+		return errUnavailable
+	})
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, errUnavailable, err)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	start := time.Now()
+	err = retry.Do(ctx, func(ctx context.Context) error {
+		return errUnavailable
+	}, retry.WithBackoff(time.Minute, time.Minute, 1))
+	assert.Less(t, time.Since(start), time.Minute)
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Equal(t, 2, err.(*errors.MultiError).Len())
+	assert.Equal(t, retry.AbortedErrorCode, err.(*errors.MultiError).Code)
+
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	calls = 0
+	start = time.Now()
+	err = retry.Do(ctx, func(ctx context.Context) error {
+		calls += 1
+		return errUnavailable
+	}, retry.WithBackoff(time.Minute, time.Minute, 1), retry.WithJitter(0))
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, errUnavailable, err)
+}
+
+func TestDoAttemptTimeout(t *testing.T) {
+	calls := 0
+	err := retry.Do(context.Background(), func(ctx context.Context) error {
+		calls += 1
+		if calls < 3 {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		_, ok := ctx.Deadline()
+		assert.True(t, ok)
+		return nil
+	}, retry.WithAttemptTimeout(5*time.Millisecond), retry.WithBackoff(0, 0, 1))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+
+	calls = 0
+	err = retry.Do(context.Background(), func(ctx context.Context) error {
+		calls += 1
+		return context.DeadlineExceeded
+	}, retry.WithBackoff(0, 0, 1))
+	assert.Equal(t, 1, calls)
+	assert.Error(t, err)
+}
+
+func TestDoBackoff(t *testing.T) {
+	var delays []time.Duration
+	_ = retry.Do(context.Background(), func(ctx context.Context) error {
+		return errUnavailable
+	}, retry.WithMaxAttempts(6), retry.WithBackoff(time.Millisecond, 5*time.Millisecond, 2), retry.WithJitter(0),
+		retry.WithHooks(func(ctx context.Context, a retry.Attempt) {
+			delays = append(delays, a.Delay)
+		}))
+	assert.Equal(t, []time.Duration{time.Millisecond, 2 * time.Millisecond, 4 * time.Millisecond,
+		5 * time.Millisecond, 5 * time.Millisecond, 0}, delays)
+
+	delays = nil
+	_ = retry.Do(context.Background(), func(ctx context.Context) error {
+		return errUnavailable
+	}, retry.WithMaxAttempts(20), retry.WithBackoff(time.Millisecond, time.Millisecond, 1), retry.WithJitter(1),
+		retry.WithHooks(func(ctx context.Context, a retry.Attempt) {
+			if !a.Last {
+				delays = append(delays, a.Delay)
+			}
+		}))
+	for _, d := range delays {
+		assert.LessOrEqual(t, d, time.Millisecond)
+		assert.GreaterOrEqual(t, d, time.Duration(0))
+	}
+}
+
+func TestOptionsPanic(t *testing.T) {
+	assert.Panics(t, func() { retry.WithMaxAttempts(0) })
+	assert.Panics(t, func() { retry.WithBackoff(-1, 0, 2) })
+	assert.Panics(t, func() { retry.WithBackoff(0, 0, 0.5) })
+	assert.Panics(t, func() { retry.WithJitter(1.5) })
+}
+
+func TestLogHook(t *testing.T) {
+	l := logtest.Default(t)
+	hook := retry.LogHook("naruto")
+	hook(context.Background(), retry.Attempt{Number: 1, Duration: time.Millisecond})
+	hook(context.Background(), retry.Attempt{Number: 1, Err: errUnavailable, Delay: time.Millisecond})
+	hook(context.Background(), retry.Attempt{Number: 2, Err: errNotFound, Last: true})
+	assert.True(t, l.HasEntry(log.DebugLevel, "attempt succeeded", "operation", "naruto", "attempt", 1,
+		"duration", "1ms"))
+	assert.True(t, l.HasEntry(log.WarnLevel, "attempt failed, retrying", "attempt", 1, "delay", "1ms"))
+	assert.True(t, l.HasEntry(log.WarnLevel, "attempt failed", "attempt", 2, "error", errNotFound))
+
+	l.Reset()
+	err := retry.Do(context.Background(), func(ctx context.Context) error {
+		return errUnavailable
+	}, retry.WithBackoff(0, 0, 1), retry.WithHooks(hook))
+	assert.Error(t, err)
+	assert.Len(t, l.Entries(), retry.DefaultMaxAttempts)
+}
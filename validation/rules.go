@@ -0,0 +1,220 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"unicode/utf8"
+
+	"github.com/sinhashubham95/go-utils/errors"
+	"github.com/sinhashubham95/go-utils/numbers"
+	"github.com/sinhashubham95/go-utils/strings"
+)
+
+// Codes of the violations of the rules
+const (
+	RequiredCode     = "REQUIRED"
+	MinLengthCode    = "MIN_LENGTH"
+	MaxLengthCode    = "MAX_LENGTH"
+	AlphaCode        = "ALPHA"
+	NumericCode      = "NUMERIC"
+	AlphaNumericCode = "ALPHA_NUMERIC"
+	PatternCode      = "PATTERN"
+	OneOfCode        = "ONE_OF"
+	MinCode          = "MIN"
+	MaxCode          = "MAX"
+	MinItemsCode     = "MIN_ITEMS"
+	MaxItemsCode     = "MAX_ITEMS"
+	UniqueCode       = "UNIQUE"
+)
+
+// Violation is the violation of a rule by a value.
+type Violation struct {
+	// Code is the code of the rule.
+	Code string
+	// Message is the message describing the violation, which can have the named placeholders filled with the params.
+	Message string
+	// Params is the params of the rule, like the limits.
+	Params errors.Params
+}
+
+// Rule is used to check the value, returning the violation if it is not valid, and nil otherwise.
+type Rule[T any] func(value T) *Violation
+
+// NewRule is used to create the rule which is violated when valid returns false for the value,
+// with the given code, message and params.
+func NewRule[T any](code, message string, params errors.Params, valid func(value T) bool) Rule[T] {
+	return func(value T) *Violation {
+		if valid(value) {
+			return nil
+		}
+		return &Violation{Code: code, Message: message, Params: params}
+	}
+}
+
+// Check is used to check the value of the field against the rules, adding the error of the field for the first
+// rule it violates. It returns whether the value is valid.
+func Check[T any](errs *Errors, field string, value T, rules ...Rule[T]) bool {
+	if v := All(rules...)(value); v != nil {
+		errs.addViolation(field, v, value)
+		return false
+	}
+	return true
+}
+
+// CheckEach is used to check each of the values of the field against the rules, the same as Check,
+// with the path of each value being the field followed by its index. It returns whether all the values are valid.
+func CheckEach[T any](errs *Errors, field string, values []T, rules ...Rule[T]) bool {
+	valid := true
+	for i, v := range values {
+		if !Check(errs, Path(field, i), v, rules...) {
+			valid = false
+		}
+	}
+	return valid
+}
+
+// All is used to combine the rules into one, which is violated by the value violating any of them.
+// The violation is the one of the first rule violated.
+func All[T any](rules ...Rule[T]) Rule[T] {
+	return func(value T) *Violation {
+		for _, r := range rules {
+			if v := r(value); v != nil {
+				return v
+			}
+		}
+		return nil
+	}
+}
+
+// Optional is used to combine the rules into one, the same as All, which is not checked for the zero value.
+func Optional[T comparable](rules ...Rule[T]) Rule[T] {
+	all := All(rules...)
+	return func(value T) *Violation {
+		var zero T
+		if value == zero {
+			return nil
+		}
+		return all(value)
+	}
+}
+
+// OneOf is used to create the rule which is violated by the value not equal to any of the given values.
+func OneOf[T comparable](values ...T) Rule[T] {
+	return NewRule(OneOfCode, "must be one of {values}", errors.Params{"values": fmt.Sprint(values)},
+		func(value T) bool {
+			for _, v := range values {
+				if v == value {
+					return true
+				}
+			}
+			return false
+		})
+}
+
+// Required is used to create the rule which is violated by the blank string.
+func Required() Rule[string] {
+	return NewRule(RequiredCode, "is required", nil, func(value string) bool {
+		return !strings.IsBlank(value)
+	})
+}
+
+// MinLength is used to create the rule which is violated by the string having less than n characters.
+func MinLength(n int) Rule[string] {
+	return NewRule(MinLengthCode, "must be at least {min} characters long", errors.Params{"min": n},
+		func(value string) bool {
+			return utf8.RuneCountInString(value) >= n
+		})
+}
+
+// MaxLength is used to create the rule which is violated by the string having more than n characters.
+func MaxLength(n int) Rule[string] {
+	return NewRule(MaxLengthCode, "must be at most {max} characters long", errors.Params{"max": n},
+		func(value string) bool {
+			return utf8.RuneCountInString(value) <= n
+		})
+}
+
+// Alpha is used to create the rule which is violated by the string having the characters other than the letters.
+func Alpha() Rule[string] {
+	return NewRule(AlphaCode, "must contain only letters", nil, strings.IsAlpha)
+}
+
+// Numeric is used to create the rule which is violated by the string having the characters other than the digits.
+func Numeric() Rule[string] {
+	return NewRule(NumericCode, "must contain only digits", nil, strings.IsNumeric)
+}
+
+// AlphaNumeric is used to create the rule which is violated by the string having the characters other than the
+// letters and the digits.
+func AlphaNumeric() Rule[string] {
+	return NewRule(AlphaNumericCode, "must contain only letters and digits", nil, strings.IsAlphaNumeric)
+}
+
+// Pattern is used to create the rule which is violated by the string not matching the regular expression.
+func Pattern(re *regexp.Regexp) Rule[string] {
+	return NewRule(PatternCode, "must match {pattern}", errors.Params{"pattern": re.String()}, re.MatchString)
+}
+
+// Min is used to create the rule which is violated by the number less than min, or NaN.
+func Min[K numbers.Number](min K) Rule[K] {
+	return NewRule(MinCode, "must be at least {min}", errors.Params{"min": min}, func(value K) bool {
+		// NaN is not comparable with the limit, so it is rejected explicitly
+		return value == value && numbers.Compare(value, min) >= 0
+	})
+}
+
+// Max is used to create the rule which is violated by the number greater than max, or NaN.
+func Max[K numbers.Number](max K) Rule[K] {
+	return NewRule(MaxCode, "must be at most {max}", errors.Params{"max": max}, func(value K) bool {
+		// NaN is not comparable with the limit, so it is rejected explicitly
+		return value == value && numbers.Compare(value, max) <= 0
+	})
+}
+
+// Between is used to create the rule which is violated by the number not in the range from min to max, inclusive,
+// or NaN.
+func Between[K numbers.Number](min, max K) Rule[K] {
+	return All(Min(min), Max(max))
+}
+
+// MinItems is used to create the rule which is violated by the slice having less than n items.
+func MinItems[T any](n int) Rule[[]T] {
+	r := minItems(n)
+	return func(value []T) *Violation {
+		return r(len(value))
+	}
+}
+
+// MaxItems is used to create the rule which is violated by the slice having more than n items.
+func MaxItems[T any](n int) Rule[[]T] {
+	r := maxItems(n)
+	return func(value []T) *Violation {
+		return r(len(value))
+	}
+}
+
+// Unique is used to create the rule which is violated by the slice having the same item more than once.
+func Unique[T comparable]() Rule[[]T] {
+	return NewRule(UniqueCode, "must have unique items", nil, func(value []T) bool {
+		seen := make(map[T]struct{}, len(value))
+		for _, v := range value {
+			if _, ok := seen[v]; ok {
+				return false
+			}
+			seen[v] = struct{}{}
+		}
+		return true
+	})
+}
+
+func minItems(n int) Rule[int] {
+	return NewRule(MinItemsCode, "must have at least {min} items", errors.Params{"min": n}, func(length int) bool {
+		return length >= n
+	})
+}
+
+func maxItems(n int) Rule[int] {
+	return NewRule(MaxItemsCode, "must have at most {max} items", errors.Params{"max": n}, func(length int) bool {
+		return length <= n
+	})
+}
@@ -0,0 +1,94 @@
+package validation_test
+
+import (
+	"math"
+	"regexp"
+	"testing"
+
+	"github.com/sinhashubham95/go-utils/errors"
+	"github.com/sinhashubham95/go-utils/validation"
+	"github.com/stretchr/testify/assert"
+)
+
+func violation[T any](rule validation.Rule[T], value T) string {
+	if v := rule(value); v != nil {
+		return v.Code
+	}
+	return ""
+}
+
+func TestStringRules(t *testing.T) {
+	assert.Equal(t, validation.RequiredCode, violation(validation.Required(), " \t"))
+	assert.Equal(t, "", violation(validation.Required(), "naruto"))
+	assert.Equal(t, validation.MinLengthCode, violation(validation.MinLength(3), "ab"))
+	assert.Equal(t, "", violation(validation.MinLength(3), "abc"))
+	assert.Equal(t, validation.MaxLengthCode, violation(validation.MaxLength(3), "abcd"))
+	assert.Equal(t, "", violation(validation.MaxLength(3), "äöü"))
+	assert.Equal(t, validation.AlphaCode, violation(validation.Alpha(), "naruto1"))
+	assert.Equal(t, "", violation(validation.Alpha(), "naruto"))
+	assert.Equal(t, validation.NumericCode, violation(validation.Numeric(), "12a"))
+	assert.Equal(t, "", violation(validation.Numeric(), "123"))
+	assert.Equal(t, validation.AlphaNumericCode, violation(validation.AlphaNumeric(), "naruto-1"))
+	assert.Equal(t, "", violation(validation.AlphaNumeric(), "naruto1"))
+	assert.Equal(t, validation.PatternCode, violation(validation.Pattern(regexp.MustCompile(`^[a-z]+$`)), "Naruto"))
+	assert.Equal(t, validation.OneOfCode, violation(validation.OneOf("a", "b"), "c"))
+	assert.Equal(t, "", violation(validation.OneOf("a", "b"), "b"))
+}
+
+func TestNumberRules(t *testing.T) {
+	assert.Equal(t, validation.MinCode, violation(validation.Min(1), 0))
+	assert.Equal(t, "", violation(validation.Min(1), 1))
+	assert.Equal(t, validation.MaxCode, violation(validation.Max(1.5), 1.6))
+	assert.Equal(t, "", violation(validation.Max(1.5), 1.5))
+	assert.Equal(t, validation.MinCode, violation(validation.Between[uint8](2, 4), 1))
+	assert.Equal(t, validation.MaxCode, violation(validation.Between[uint8](2, 4), 5))
+	assert.Equal(t, "", violation(validation.Between[uint8](2, 4), 3))
+
+	nan := math.NaN()
+	assert.Equal(t, validation.MinCode, violation(validation.Min(1.5), nan))
+	assert.Equal(t, validation.MaxCode, violation(validation.Max(1.5), nan))
+	assert.Equal(t, validation.MinCode, violation(validation.Between(-1.0, 1.0), nan))
+	assert.Equal(t, validation.MaxCode, violation(validation.Max(float32(1)), float32(nan)))
+	assert.Equal(t, "", violation(validation.Max(math.Inf(1)), math.Inf(1)))
+}
+
+func TestSliceRules(t *testing.T) {
+	assert.Equal(t, validation.MinItemsCode, violation(validation.MinItems[int](1), nil))
+	assert.Equal(t, "", violation(validation.MinItems[int](1), []int{1}))
+	assert.Equal(t, validation.MaxItemsCode, violation(validation.MaxItems[int](1), []int{1, 2}))
+	assert.Equal(t, validation.UniqueCode, violation(validation.Unique[string](), []string{"a", "b", "a"}))
+	assert.Equal(t, "", violation(validation.Unique[string](), []string{"a", "b"}))
+}
+
+func TestCombinedRules(t *testing.T) {
+	rule := validation.All(validation.Required(), validation.MaxLength(3), validation.Alpha())
+	assert.Equal(t, validation.RequiredCode, violation(rule, ""))
+	assert.Equal(t, validation.MaxLengthCode, violation(rule, "naruto"))
+	assert.Equal(t, validation.AlphaCode, violation(rule, "ab1"))
+
+	optional := validation.Optional(validation.MinLength(3))
+	assert.Equal(t, "", violation(optional, ""))
+	assert.Equal(t, validation.MinLengthCode, violation(optional, "ab"))
+
+	even := validation.NewRule("EVEN", "must be even, got {value}", errors.Params{"value": 3},
+		func(value int) bool { return value%2 == 0 })
+	assert.Equal(t, &validation.Violation{Code: "EVEN", Message: "must be even, got {value}",
+		Params: errors.Params{"value": 3}}, even(3))
+	assert.Nil(t, even(2))
+}
+
+func TestCheck(t *testing.T) {
+	var errs validation.Errors
+	assert.True(t, validation.Check(&errs, "name", "naruto", validation.Required(), validation.MaxLength(64)))
+	assert.False(t, validation.Check(&errs, "code", "ab", validation.MinLength(3), validation.Numeric()))
+	assert.False(t, validation.CheckEach(&errs, "quantities", []int{1, 0, -1}, validation.Min(1)))
+	assert.True(t, validation.CheckEach(&errs, "tags", []string{"a"}, validation.Required()))
+	assert.Equal(t, validation.Errors{
+		{Field: "code", Code: validation.MinLengthCode, Message: "must be at least 3 characters long",
+			Params: errors.Params{"min": 3}, Value: "ab"},
+		{Field: "quantities[1]", Code: validation.MinCode, Message: "must be at least 1",
+			Params: errors.Params{"min": 1}, Value: 0},
+		{Field: "quantities[2]", Code: validation.MinCode, Message: "must be at least 1",
+			Params: errors.Params{"min": 1}, Value: -1},
+	}, errs)
+}
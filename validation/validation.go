@@ -0,0 +1,155 @@
+// Package validation is used to validate the input, gathering the errors of each of its fields and rolling them up
+// into a single error, so that all the handlers respond with the same body for the invalid input.
+//
+// The rules are built for the strings, the numbers and the slices, and checked against the fields, for example
+//
+//	var errs validation.Errors
+//	validation.Check(&errs, "name", req.Name, validation.Required(), validation.MaxLength(64))
+//	for i, item := range req.Items {
+//		validation.Check(&errs, validation.Path("items", i, "price"), item.Price, validation.Min(0.01))
+//	}
+//	if err := errs.Err(); err != nil {
+//		return err
+//	}
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/sinhashubham95/go-utils/errors"
+)
+
+// ErrorCode is the code of the error rolling up the errors of the fields.
+const ErrorCode = "VALIDATION_FAILED"
+
+// ErrValidation is the error rolling up the errors of the fields, to be compared with using errors.Is.
+var ErrValidation = &errors.Error{
+	StatusCode: http.StatusBadRequest,
+	Code:       ErrorCode,
+	Message:    "validation failed",
+	Severity:   errors.SeverityWarning,
+	Category:   errors.CategoryValidation,
+}
+
+// FieldError is the error of a field of the input.
+type FieldError struct {
+	// Field is the path of the field, for example items[3].price.
+	Field string `json:"field"`
+	// Code is the code of the rule the field violates.
+	Code string `json:"code"`
+	// Message is the rendered message describing the violation.
+	Message string `json:"message"`
+	// Params is the params of the rule, with which the message is rendered.
+	Params errors.Params `json:"params,omitempty"`
+	// Value is the rejected value of the field.
+	Value interface{} `json:"value"`
+}
+
+// Error is used to get the field along with the message.
+func (f FieldError) Error() string {
+	if f.Field == "" {
+		return f.Message
+	}
+	return fmt.Sprintf("%s: %s", f.Field, f.Message)
+}
+
+// Errors is the collection of the errors of the fields of the input. The zero value is ready to use.
+type Errors []FieldError
+
+// Add is used to add the error of the field.
+func (e *Errors) Add(field, code, message string, value interface{}) {
+	*e = append(*e, FieldError{Field: field, Code: code, Message: message, Value: value})
+}
+
+// Merge is used to add the errors of a nested input, with their fields prefixed by the path of the input.
+func (e *Errors) Merge(prefix string, errs Errors) {
+	for _, f := range errs {
+		f.Field = join(prefix, f.Field)
+		*e = append(*e, f)
+	}
+}
+
+// Len is used to get the number of the errors.
+func (e Errors) Len() int {
+	return len(e)
+}
+
+// Err is used to roll up the errors into an *errors.Error having the code VALIDATION_FAILED, the status code 400,
+// and the errors of the fields in the details. It is nil if there are no errors.
+func (e Errors) Err() error {
+	return e.err(1)
+}
+
+// Fields is used to get the errors of the fields from the error created using Errors.Err.
+// It also works for the error parsed from the response, like the one returned by httperr.ParseResponse,
+// in which the details are decoded from JSON. It is nil if there is no such error in the chain.
+func Fields(err error) Errors {
+	var e *errors.Error
+	if !errors.As(err, &e) || e.Code != ErrorCode {
+		return nil
+	}
+	if errs, ok := e.Details.(Errors); ok {
+		return errs
+	}
+	b, err := json.Marshal(e.Details)
+	if err != nil {
+		return nil
+	}
+	var errs Errors
+	if json.Unmarshal(b, &errs) != nil {
+		return nil
+	}
+	return errs
+}
+
+// Path is used to create the path of a field from its elements. The strings are the names of the fields,
+// separated by dots, and the other elements are the indices or the keys, in brackets,
+// for example Path("items", 3, "price") is items[3].price.
+func Path(elements ...interface{}) string {
+	var b strings.Builder
+	for _, e := range elements {
+		if s, ok := e.(string); ok {
+			if b.Len() > 0 {
+				b.WriteByte('.')
+			}
+			b.WriteString(s)
+			continue
+		}
+		_, _ = fmt.Fprintf(&b, "[%v]", e)
+	}
+	return b.String()
+}
+
+// err is used to roll up the errors, with the stack trace starting skip frames above the caller.
+func (e Errors) err(skip int) error {
+	if len(e) == 0 {
+		return nil
+	}
+	return ErrValidation.WithDetails(e).SkipFrames(skip + 1)
+}
+
+func (e *Errors) addViolation(field string, v *Violation, value interface{}) {
+	*e = append(*e, FieldError{
+		Field:   field,
+		Code:    v.Code,
+		Message: errors.Render(v.Message, v.Params),
+		Params:  v.Params,
+		Value:   value,
+	})
+}
+
+func join(prefix, field string) string {
+	switch {
+	case prefix == "":
+		return field
+	case field == "":
+		return prefix
+	case field[0] == '[':
+		return prefix + field
+	default:
+		return prefix + "." + field
+	}
+}
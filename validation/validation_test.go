@@ -0,0 +1,77 @@
+package validation_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sinhashubham95/go-utils/errors"
+	"github.com/sinhashubham95/go-utils/errors/httperr"
+	"github.com/sinhashubham95/go-utils/validation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPath(t *testing.T) {
+	assert.Equal(t, "", validation.Path())
+	assert.Equal(t, "name", validation.Path("name"))
+	assert.Equal(t, "items[3].price", validation.Path("items", 3, "price"))
+	assert.Equal(t, "matrix[1][2]", validation.Path("matrix", 1, 2))
+	assert.Equal(t, "[1].name", validation.Path(1, "name"))
+}
+
+func TestErrorsErr(t *testing.T) {
+	var errs validation.Errors
+	assert.NoError(t, errs.Err())
+	assert.Nil(t, validation.Fields(nil))
+
+	errs.Add("name", validation.RequiredCode, "is required", "")
+	var address validation.Errors
+	address.Add("city", validation.RequiredCode, "is required", " ")
+	address.Add("[0]", validation.MinCode, "must be at least 1", 0)
+	errs.Merge("address", address)
+	assert.Equal(t, 3, errs.Len())
+
+	err := errs.Err()
+	assert.True(t, errors.Is(err, validation.ErrValidation))
+	assert.Equal(t, http.StatusBadRequest, errors.StatusCode(err))
+	assert.Equal(t, errors.CategoryValidation, errors.Classify(err))
+	assert.Equal(t, "validation failed", err.(*errors.Error).Message)
+	assert.NotEmpty(t, err.(*errors.Error).Frames())
+	assert.Equal(t, validation.Errors{
+		{Field: "name", Code: validation.RequiredCode, Message: "is required", Value: ""},
+		{Field: "address.city", Code: validation.RequiredCode, Message: "is required", Value: " "},
+		{Field: "address[0]", Code: validation.MinCode, Message: "must be at least 1", Value: 0},
+	}, validation.Fields(fmt.Errorf("wrapped: %w", err)))
+	assert.Equal(t, "address.city: is required", errs[1].Error())
+
+	assert.Nil(t, validation.Fields(errors.New("naruto")))
+}
+
+func TestErrorsResponse(t *testing.T) {
+	var errs validation.Errors
+	validation.Check(&errs, validation.Path("items", 3, "price"), -1, validation.Min(0))
+
+	w := httptest.NewRecorder()
+	httperr.Write(w, httptest.NewRequest(http.MethodPost, "/orders", nil), errs.Err())
+	var body map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"field":   "items[3].price",
+		"code":    validation.MinCode,
+		"message": "must be at least 0",
+		"params":  map[string]interface{}{"min": float64(0)},
+		"value":   float64(-1),
+	}}, body[httperr.DetailsMember])
+
+	err, perr := httperr.ParseResponse(w.Result())
+	assert.NoError(t, perr)
+	assert.Equal(t, validation.Errors{{
+		Field:   "items[3].price",
+		Code:    validation.MinCode,
+		Message: "must be at least 0",
+		Params:  errors.Params{"min": float64(0)},
+		Value:   float64(-1),
+	}}, validation.Fields(err))
+}
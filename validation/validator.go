@@ -0,0 +1,303 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/sinhashubham95/go-utils/numbers"
+)
+
+// TagName is the name of the tag holding the rules of the fields.
+const TagName = "validate"
+
+// plans of the struct types, compiled from the tags once per type
+var plans sync.Map
+
+type structPlan struct {
+	fields []fieldPlan
+}
+
+type fieldPlan struct {
+	index     int
+	name      string
+	required  bool
+	omitEmpty bool
+	rules     []Rule[reflect.Value]
+	// nested tells whether the field can hold the structs to be validated
+	nested bool
+}
+
+// Validate is used to validate the struct, or the pointer to it, against the rules in the validate tags of its
+// fields, for example
+//
+//	type User struct {
+//		Name  string   `json:"name" validate:"required,min=1,max=64,alphanum"`
+//		Role  string   `json:"role" validate:"oneof=admin|member"`
+//		Age   *int     `json:"age" validate:"omitempty,min=18"`
+//		Tags  []string `json:"tags" validate:"max=8"`
+//		Roles []Role   `json:"roles"`
+//	}
+//
+// The rules are separated by commas, and each of them is one of
+//   - required, violated by the zero value, the blank string, the nil pointer and the empty slice or map,
+//   - omitempty, skipping the other rules for the zero value,
+//   - min=n and max=n, limiting the numbers, the length of the strings and the number of items of the slices,
+//     arrays and maps,
+//   - alpha, numeric and alphanum, limiting the characters of the strings,
+//   - oneof=a|b, limiting the strings and the numbers to the given values.
+//
+// The rules of a pointer field apply to the value it points to, and are skipped for the nil pointer, unless it is
+// required. The nested structs, including the ones in the slices, arrays and maps, are validated as well.
+// The fields are named by their json tags, or their names if they do not have one.
+//
+// The errors of the fields are rolled up into an *errors.Error, the same as Errors.Err. It is nil if the value is
+// valid. The tags are compiled only once for each type, and it panics if they are invalid.
+func Validate(v interface{}) error {
+	var errs Errors
+	walk(&errs, "", reflect.ValueOf(v))
+	return errs.err(1)
+}
+
+func walk(errs *Errors, path string, v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			walk(errs, path, v.Elem())
+		}
+	case reflect.Struct:
+		planOf(v.Type()).check(errs, path, v)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i += 1 {
+			walk(errs, fmt.Sprintf("%s[%d]", path, i), v.Index(i))
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		// the keys are sorted for the errors to be in the same order every time
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+		})
+		for _, k := range keys {
+			walk(errs, fmt.Sprintf("%s[%v]", path, k), v.MapIndex(k))
+		}
+	}
+}
+
+func planOf(t reflect.Type) *structPlan {
+	if p, ok := plans.Load(t); ok {
+		return p.(*structPlan)
+	}
+	p, _ := plans.LoadOrStore(t, compile(t))
+	return p.(*structPlan)
+}
+
+func (p *structPlan) check(errs *Errors, path string, v reflect.Value) {
+	for _, f := range p.fields {
+		fv := v.Field(f.index)
+		field := join(path, f.name)
+		f.check(errs, field, fv)
+		if f.nested {
+			walk(errs, field, fv)
+		}
+	}
+}
+
+func (f *fieldPlan) check(errs *Errors, field string, v reflect.Value) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			if f.required {
+				errs.addViolation(field, &Violation{Code: RequiredCode, Message: "is required"}, nil)
+			}
+			return
+		}
+		v = v.Elem()
+	}
+	if f.omitEmpty && isEmpty(v) {
+		return
+	}
+	for _, r := range f.rules {
+		if violation := r(v); violation != nil {
+			errs.addViolation(field, violation, v.Interface())
+			return
+		}
+	}
+}
+
+func compile(t reflect.Type) *structPlan {
+	p := &structPlan{}
+	for i := 0; i < t.NumField(); i += 1 {
+		sf := t.Field(i)
+		tag := sf.Tag.Get(TagName)
+		if !sf.IsExported() || tag == "-" {
+			continue
+		}
+		f := fieldPlan{index: i, name: fieldName(sf), nested: canNest(sf.Type)}
+		ft := sf.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		for _, rule := range strings.Split(tag, ",") {
+			if rule == "" {
+				continue
+			}
+			switch rule {
+			case "required":
+				f.required = true
+				f.rules = append(f.rules, required(ft))
+			case "omitempty":
+				f.omitEmpty = true
+			default:
+				f.rules = append(f.rules, compileRule(t, sf, ft, rule))
+			}
+		}
+		if len(f.rules) > 0 || f.nested {
+			p.fields = append(p.fields, f)
+		}
+	}
+	return p
+}
+
+func compileRule(t reflect.Type, sf reflect.StructField, ft reflect.Type, rule string) Rule[reflect.Value] {
+	name, param, _ := strings.Cut(rule, "=")
+	var r Rule[reflect.Value]
+	switch name {
+	case "min", "max":
+		r = limit(ft, name == "min", param)
+	case "alpha":
+		r = stringRule(ft, Alpha())
+	case "numeric":
+		r = stringRule(ft, Numeric())
+	case "alphanum":
+		r = stringRule(ft, AlphaNumeric())
+	case "oneof":
+		r = oneOf(ft, strings.Split(param, "|"))
+	}
+	if r == nil {
+		panic(fmt.Sprintf("invalid validation rule %q on field %s of type %s", rule, sf.Name, t))
+	}
+	return r
+}
+
+func required(t reflect.Type) Rule[reflect.Value] {
+	if t.Kind() == reflect.String {
+		return adapt(Required(), reflect.Value.String)
+	}
+	return NewRule(RequiredCode, "is required", nil, func(v reflect.Value) bool {
+		return !isEmpty(v)
+	})
+}
+
+func limit(t reflect.Type, min bool, param string) Rule[reflect.Value] {
+	switch t.Kind() {
+	case reflect.String:
+		n, err := strconv.Atoi(param)
+		if err != nil {
+			return nil
+		}
+		if min {
+			return adapt(MinLength(n), reflect.Value.String)
+		}
+		return adapt(MaxLength(n), reflect.Value.String)
+	case reflect.Slice, reflect.Array, reflect.Map:
+		n, err := strconv.Atoi(param)
+		if err != nil {
+			return nil
+		}
+		if min {
+			return adapt(minItems(n), reflect.Value.Len)
+		}
+		return adapt(maxItems(n), reflect.Value.Len)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return numberLimit(min, param, reflect.Value.Int)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return numberLimit(min, param, reflect.Value.Uint)
+	case reflect.Float32, reflect.Float64:
+		return numberLimit(min, param, reflect.Value.Float)
+	}
+	return nil
+}
+
+func numberLimit[K numbers.Number](min bool, param string, get func(reflect.Value) K) Rule[reflect.Value] {
+	n, err := numbers.StringToNumber[K](param)
+	if err != nil {
+		return nil
+	}
+	if min {
+		return adapt(Min(n), get)
+	}
+	return adapt(Max(n), get)
+}
+
+func stringRule(t reflect.Type, r Rule[string]) Rule[reflect.Value] {
+	if t.Kind() != reflect.String {
+		return nil
+	}
+	return adapt(r, reflect.Value.String)
+}
+
+func oneOf(t reflect.Type, values []string) Rule[reflect.Value] {
+	switch t.Kind() {
+	case reflect.String:
+		return adapt(OneOf(values...), reflect.Value.String)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return numberOneOf(values, reflect.Value.Int)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return numberOneOf(values, reflect.Value.Uint)
+	case reflect.Float32, reflect.Float64:
+		return numberOneOf(values, reflect.Value.Float)
+	}
+	return nil
+}
+
+func numberOneOf[K numbers.Number](values []string, get func(reflect.Value) K) Rule[reflect.Value] {
+	ns := make([]K, len(values))
+	for i, v := range values {
+		n, err := numbers.StringToNumber[K](v)
+		if err != nil {
+			return nil
+		}
+		ns[i] = n
+	}
+	return adapt(OneOf(ns...), get)
+}
+
+func adapt[T any](r Rule[T], get func(reflect.Value) T) Rule[reflect.Value] {
+	return func(v reflect.Value) *Violation {
+		return r(get(v))
+	}
+}
+
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
+
+// canNest returns whether the values of the type can hold the structs.
+func canNest(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Struct, reflect.Interface:
+		return true
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		return canNest(t.Elem())
+	}
+	return false
+}
+
+func fieldName(sf reflect.StructField) string {
+	name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+	switch {
+	case name != "" && name != "-":
+		return name
+	case sf.Anonymous:
+		// the fields of the embedded structs are promoted, the same as json does
+		return ""
+	default:
+		return sf.Name
+	}
+}
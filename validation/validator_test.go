@@ -0,0 +1,154 @@
+package validation_test
+
+import (
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/sinhashubham95/go-utils/errors"
+	"github.com/sinhashubham95/go-utils/validation"
+	"github.com/stretchr/testify/assert"
+)
+
+type Audit struct {
+	CreatedBy string `json:"createdBy" validate:"required"`
+}
+
+type item struct {
+	SKU      string  `json:"sku" validate:"required,alphanum"`
+	Price    float64 `json:"price" validate:"min=0.01"`
+	Quantity uint8   `json:"quantity" validate:"min=1,max=10"`
+}
+
+type order struct {
+	Audit
+	ID       string            `json:"id" validate:"required,min=4,max=8,numeric"`
+	Status   string            `json:"status" validate:"oneof=new|paid"`
+	Priority int               `json:"priority" validate:"oneof=1|2|3"`
+	Note     *string           `json:"note" validate:"omitempty,min=3"`
+	Coupon   *string           `validate:"required,alpha"`
+	Items    []item            `json:"items" validate:"min=1,max=2"`
+	Gifts    map[string]*item  `json:"gifts"`
+	Labels   map[string]string `json:"labels" validate:"max=1"`
+	Ignored  string            `json:"ignored" validate:"-"`
+	internal string
+}
+
+func TestValidate(t *testing.T) {
+	coupon := "abc"
+	valid := order{
+		Audit:    Audit{CreatedBy: "naruto"},
+		ID:       "1234",
+		Status:   "new",
+		Priority: 2,
+		Coupon:   &coupon,
+		Items:    []item{{SKU: "a1", Price: 1, Quantity: 1}},
+		Gifts:    map[string]*item{"x": {SKU: "b2", Price: 0.01, Quantity: 10}},
+	}
+	assert.NoError(t, validation.Validate(valid))
+	assert.NoError(t, validation.Validate(&valid))
+	assert.NoError(t, validation.Validate(nil))
+	assert.NoError(t, validation.Validate((*order)(nil)))
+
+	note, bad := "ab", "ab1"
+	invalid := order{
+		ID:       "12a",
+		Status:   "shipped",
+		Priority: 4,
+		Note:     &note,
+		Items:    []item{{SKU: "a-1", Price: 0, Quantity: 0}, {SKU: " ", Price: 1, Quantity: 11}, {}},
+		Gifts:    map[string]*item{"y": {SKU: "b2", Quantity: 1}, "x": nil},
+		Labels:   map[string]string{"a": "b", "c": "d"},
+		Ignored:  "",
+		internal: "",
+	}
+	err := validation.Validate(&invalid)
+	assert.True(t, errors.Is(err, validation.ErrValidation))
+	assert.Equal(t, http.StatusBadRequest, errors.StatusCode(err))
+	assert.True(t, strings.HasSuffix(err.(*errors.Error).Frames()[0].Function, "TestValidate"))
+
+	fields := validation.Fields(err)
+	codes := make(map[string]string, len(fields))
+	for _, f := range fields {
+		codes[f.Field] = f.Code
+	}
+	assert.Equal(t, map[string]string{
+		"createdBy":         validation.RequiredCode,
+		"id":                validation.MinLengthCode,
+		"status":            validation.OneOfCode,
+		"priority":          validation.OneOfCode,
+		"note":              validation.MinLengthCode,
+		"Coupon":            validation.RequiredCode,
+		"items":             validation.MaxItemsCode,
+		"items[0].sku":      validation.AlphaNumericCode,
+		"items[0].price":    validation.MinCode,
+		"items[0].quantity": validation.MinCode,
+		"items[1].sku":      validation.RequiredCode,
+		"items[1].quantity": validation.MaxCode,
+		"items[2].sku":      validation.RequiredCode,
+		"items[2].price":    validation.MinCode,
+		"items[2].quantity": validation.MinCode,
+		"gifts[y].price":    validation.MinCode,
+		"labels":            validation.MaxItemsCode,
+	}, codes)
+	assert.Equal(t, validation.FieldError{Field: "note", Code: validation.MinLengthCode,
+		Message: "must be at least 3 characters long", Params: errors.Params{"min": 3}, Value: "ab"}, fields[4])
+
+	invalid.Coupon = &bad
+	fields = validation.Fields(validation.Validate([]order{invalid}))
+	assert.Equal(t, "[0].createdBy", fields[0].Field)
+	assert.Equal(t, validation.FieldError{Field: "[0].Coupon", Code: validation.AlphaCode,
+		Message: "must contain only letters", Value: "ab1"}, fields[5])
+}
+
+func TestValidateNaN(t *testing.T) {
+	fields := validation.Fields(validation.Validate(item{SKU: "a1", Price: math.NaN(), Quantity: 1}))
+	assert.Len(t, fields, 1)
+	assert.Equal(t, "price", fields[0].Field)
+	assert.Equal(t, validation.MinCode, fields[0].Code)
+
+	fields = validation.Fields(validation.Validate(struct {
+		Ratio float32 `json:"ratio" validate:"max=1"`
+	}{Ratio: float32(math.NaN())}))
+	assert.Len(t, fields, 1)
+	assert.Equal(t, validation.MaxCode, fields[0].Code)
+}
+
+func TestValidateInvalidTags(t *testing.T) {
+	assert.Panics(t, func() {
+		_ = validation.Validate(struct {
+			Age int `validate:"alpha"`
+		}{})
+	})
+	assert.Panics(t, func() {
+		_ = validation.Validate(struct {
+			Age int `validate:"min=a"`
+		}{})
+	})
+	assert.Panics(t, func() {
+		_ = validation.Validate(struct {
+			Email string `validate:"email"`
+		}{})
+	})
+}
+
+func TestValidateConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i += 1 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Error(t, validation.Validate(item{}))
+		}()
+	}
+	wg.Wait()
+}
+
+func BenchmarkValidate(b *testing.B) {
+	v := item{SKU: "a1", Price: 1, Quantity: 1}
+	for i := 0; i < b.N; i += 1 {
+		_ = validation.Validate(&v)
+	}
+}